@@ -0,0 +1,113 @@
+package chain
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+// Page sizes of GetTransactionsByAddress
+const (
+	defaultAddressTransactionsLimit = 20
+	maxAddressTransactionsLimit     = 100
+)
+
+// Roles of address in tx, empty role matches any of them
+var addressRoles = map[string]storage.AddressRole{
+	"sender":   storage.AddressRoleSender,
+	"receiver": storage.AddressRoleReceiver,
+	"event":    storage.AddressRoleEvent,
+}
+
+// GetTransactionsByAddressParams is params to GetTransactionsByAddress, Before is position of
+// the page start returned as Next by previous page of the same role, zero starts from the latest transaction
+type GetTransactionsByAddressParams struct {
+	Address string `json:"address"`
+	Role    string `json:"role"`
+	Before  uint64 `json:"before"`
+	Limit   int    `json:"limit"`
+}
+
+type addressTransaction struct {
+	Roles       []string     `json:"roles"`
+	Transaction *transaction `json:"transaction"`
+	Receipt     *receipt     `json:"receipt"`
+}
+
+// GetTransactionsByAddressResult is result of GetTransactionsByAddress, Next is set when older transactions remain
+type GetTransactionsByAddressResult struct {
+	Transactions []addressTransaction `json:"transactions"`
+	Next         uint64               `json:"next,omitempty"`
+}
+
+// GetTransactionsByAddress pages through transactions involving address, latest first
+func (service *Service) GetTransactionsByAddress(r *http.Request, params *GetTransactionsByAddressParams, result *GetTransactionsByAddressResult) error {
+	service.syncLatestState()
+	address, err := crypto.AddressFromString(params.Address)
+	if err != nil {
+		return err
+	}
+	role, ok := addressRoles[params.Role]
+	if params.Role != "" && !ok {
+		return fmt.Errorf("unknown role %s", params.Role)
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultAddressTransactionsLimit
+	}
+	if limit > maxAddressTransactionsLimit {
+		limit = maxAddressTransactionsLimit
+	}
+
+	// Role filter pages through positions in transactions of that role
+	count := service.meta.AddressTransactionCount
+	lookup := service.meta.AddressTransaction
+	if role != 0 {
+		count = func(address crypto.Address) uint64 {
+			return service.meta.AddressRoleTransactionCount(address, role)
+		}
+		lookup = func(address crypto.Address, position uint64) (common.Hash, storage.AddressRole, error) {
+			return service.meta.AddressRoleTransaction(address, role, position)
+		}
+	}
+
+	position := count(address)
+	if params.Before > 0 && params.Before < position {
+		position = params.Before
+	}
+	result.Transactions = []addressTransaction{}
+	for position > 0 {
+		if len(result.Transactions) == limit {
+			result.Next = position
+			break
+		}
+		position--
+		txHash, roles, err := lookup(address, position)
+		if err != nil {
+			return err
+		}
+		parsedTx, parsedReceipt, err := service.getTransaction(txHash)
+		if err != nil {
+			return err
+		}
+		result.Transactions = append(result.Transactions, addressTransaction{
+			Roles:       parseAddressRoles(roles),
+			Transaction: parsedTx,
+			Receipt:     parsedReceipt,
+		})
+	}
+	return nil
+}
+
+func parseAddressRoles(roles storage.AddressRole) []string {
+	parsed := []string{}
+	for _, name := range []string{"sender", "receiver", "event"} {
+		if roles&addressRoles[name] != 0 {
+			parsed = append(parsed, name)
+		}
+	}
+	return parsed
+}
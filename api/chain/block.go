@@ -41,6 +41,12 @@ func (service *Service) GetLatestBlock(r *http.Request, params *LatestBlockParam
 	}
 	block.AddReceipts(receipts...)
 
+	systemReceipts, err := service.block.GetSystemReceipts(block)
+	if err != nil {
+		return err
+	}
+	block.AddSystemReceipts(systemReceipts...)
+
 	parsedBlock, err := service.parseBlock(block)
 	if err != nil {
 		return err
@@ -75,6 +81,12 @@ func (service *Service) GetBlockByHeight(r *http.Request, params *BlockByHeightP
 	}
 	block.AddReceipts(receipts...)
 
+	systemReceipts, err := service.block.GetSystemReceipts(block)
+	if err != nil {
+		return err
+	}
+	block.AddSystemReceipts(systemReceipts...)
+
 	parsedBlock, err := service.parseBlock(block)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/QuoineFinancial/liquid-chain/abi"
@@ -28,6 +29,9 @@ func (service *Service) GetContract(r *http.Request, params *GetContractParams,
 	if err != nil {
 		return err
 	}
+	if account == nil {
+		return errors.New("contract not found")
+	}
 	contract, err := account.GetContract()
 	if err != nil {
 		return err
@@ -35,3 +39,42 @@ func (service *Service) GetContract(r *http.Request, params *GetContractParams,
 	result.Contract = contract
 	return nil
 }
+
+// GetContractHistoryParams is params to GetContractHistory
+type GetContractHistoryParams struct {
+	Address string `json:"address"`
+}
+
+// GetContractHistoryResult is result of GetContractHistory
+type GetContractHistoryResult struct {
+	ContractHash string   `json:"contractHash"`
+	History      []string `json:"history"`
+	Creator      string   `json:"creator"`
+	Admin        string   `json:"admin,omitempty"`
+}
+
+// GetContractHistory returns current and replaced code hashes of contract, oldest first
+func (service *Service) GetContractHistory(r *http.Request, params *GetContractHistoryParams, result *GetContractHistoryResult) error {
+	service.syncLatestState()
+	address, err := crypto.AddressFromString(params.Address)
+	if err != nil {
+		return err
+	}
+	account, err := service.state.GetAccount(address)
+	if err != nil {
+		return err
+	}
+	if account == nil || !account.IsContract() {
+		return errors.New("contract not found")
+	}
+	result.ContractHash = account.ContractHash.String()
+	result.History = make([]string, len(account.CodeHistory))
+	for i, hash := range account.CodeHistory {
+		result.History[i] = hash.String()
+	}
+	result.Creator = account.Creator.String()
+	if account.Admin != crypto.EmptyAddress {
+		result.Admin = account.Admin.String()
+	}
+	return nil
+}
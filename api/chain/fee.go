@@ -0,0 +1,38 @@
+package chain
+
+import (
+	"net/http"
+
+	"github.com/QuoineFinancial/liquid-chain/consensus"
+)
+
+// GetBaseFeeParams is params for GetBaseFee request
+type GetBaseFeeParams struct{}
+
+// GetBaseFeeResult is response of GetBaseFee
+type GetBaseFeeResult struct {
+	Height      uint64 `json:"height"`
+	BaseFee     uint32 `json:"baseFee"`
+	GasUsed     uint64 `json:"gasUsed"`
+	NextBaseFee uint32 `json:"nextBaseFee"`
+}
+
+// GetBaseFee returns base fee of latest block and the one expected for next block
+func (service *Service) GetBaseFee(r *http.Request, params *GetBaseFeeParams, result *GetBaseFeeResult) error {
+	genesis, err := consensus.ParseGenesis(service.meta.Genesis())
+	if err != nil {
+		return err
+	}
+
+	blockHash := service.meta.BlockHeightToBlockHash(service.meta.LatestBlockHeight())
+	block, err := service.block.GetBlock(blockHash)
+	if err != nil {
+		return err
+	}
+
+	result.Height = block.Height
+	result.BaseFee = block.BaseFee
+	result.GasUsed = block.GasUsed
+	result.NextBaseFee = genesis.BaseFee.Next(block.BaseFee, block.GasUsed)
+	return nil
+}
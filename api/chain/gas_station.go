@@ -0,0 +1,49 @@
+package chain
+
+import (
+	"net/http"
+
+	"github.com/QuoineFinancial/liquid-chain/consensus"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+)
+
+// Gas policy names
+const (
+	freePolicy  = "free"
+	alphaPolicy = "alpha"
+)
+
+// GetGasStationParams is params for GetGasStation request
+type GetGasStationParams struct{}
+
+// GetGasStationResult is response of GetGasStation
+type GetGasStationResult struct {
+	Height       uint64        `json:"height"`
+	Station      string        `json:"station"`
+	Policy       string        `json:"policy"`
+	ScheduleName string        `json:"scheduleName,omitempty"`
+	Schedule     *gas.Schedule `json:"schedule,omitempty"`
+}
+
+// GetGasStation returns gas station and policy active in latest block
+func (service *Service) GetGasStation(r *http.Request, params *GetGasStationParams, result *GetGasStationResult) error {
+	genesis, err := consensus.ParseGenesis(service.meta.Genesis())
+	if err != nil {
+		return err
+	}
+
+	height := service.meta.LatestBlockHeight()
+	station := gas.FreeStationID
+	if id, ok := service.meta.GasStation(height); ok {
+		station = gas.StationID(id)
+	}
+
+	result.Height = height
+	result.Station = station.String()
+	result.Policy = freePolicy
+	if station == gas.LiquidStationID || station == gas.NativeStationID {
+		result.Policy = alphaPolicy
+		result.ScheduleName, result.Schedule = genesis.GasScheduleAt(height)
+	}
+	return nil
+}
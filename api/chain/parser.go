@@ -6,10 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"sort"
 
 	"github.com/QuoineFinancial/liquid-chain/abi"
-	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/consensus"
 	"github.com/QuoineFinancial/liquid-chain/crypto"
 )
 
@@ -48,12 +47,36 @@ func (*Service) parseParam(param *abi.Parameter, value []byte) (string, error) {
 	return "", errors.New("unsupported type")
 }
 
+// rawCall keeps method ID and undecoded args when ABI is not available, such as contract self
+// destructed or pruned since, or function and event removed by a later upgrade
+func rawCall(methodID crypto.MethodID, args []byte) *call {
+	return &call{
+		MethodID: fmt.Sprintf("%x", methodID[:]),
+		RawArgs:  base64.StdEncoding.EncodeToString(args),
+	}
+}
+
+// loadContract returns nil contract when account at address no longer exists
+func (service *Service) loadContract(address crypto.Address) (*abi.Contract, error) {
+	account, err := service.state.GetAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil || !account.IsContract() {
+		return nil, nil
+	}
+	return account.GetContract()
+}
+
 func (service *Service) parseFunction(methodID crypto.MethodID, args []byte, contract *abi.Contract) (*call, error) {
 	if contract == nil {
-		return nil, nil
+		return rawCall(methodID, args), nil
 	}
 
-	function := contract.Header.Functions[methodID]
+	function, ok := contract.Header.Functions[methodID]
+	if !ok {
+		return rawCall(methodID, args), nil
+	}
 	parsedArgs, err := abi.DecodeToBytes(function.Parameters, args)
 	if err != nil {
 		return nil, err
@@ -78,17 +101,27 @@ func (service *Service) parseFunction(methodID crypto.MethodID, args []byte, con
 }
 
 func (service *Service) parseEvent(methodID crypto.MethodID, args []byte, address crypto.Address) (*call, error) {
-	account, err := service.state.GetAccount(address)
-	if err != nil {
-		return nil, err
+	header := consensus.SystemEvents
+	if address != crypto.EmptyAddress {
+		contract, err := service.loadContract(address)
+		if err != nil {
+			return nil, err
+		}
+		header = nil
+		if contract != nil {
+			header = contract.Header
+		}
 	}
 
-	contract, err := account.GetContract()
-	if err != nil {
-		return nil, err
+	var event *abi.Event
+	if header != nil {
+		event = header.Events[methodID]
+	}
+	if event == nil {
+		parsedCall := rawCall(methodID, args)
+		parsedCall.Contract = address.String()
+		return parsedCall, nil
 	}
-
-	event := contract.Header.Events[methodID]
 
 	parsedArgs, err := abi.DecodeToBytes(event.Parameters, args)
 	if err != nil {
@@ -119,32 +152,86 @@ func (service *Service) parseTransaction(tx *crypto.Transaction, blockHeight uin
 		BlockHeight: blockHeight,
 		Hash:        tx.Hash(),
 		Version:     tx.Version,
-		Sender:      crypto.AddressFromPubKey(tx.Sender.PublicKey),
+		Sender:      tx.SenderAddress(),
 		Nonce:       tx.Sender.Nonce,
 		Receiver:    tx.Receiver,
 		GasPrice:    tx.GasPrice,
 		GasLimit:    tx.GasLimit,
 		Signature:   tx.Signature,
+		Value:       tx.Value,
+		ChainID:     tx.ChainID,
+		Expiry:      tx.Expiry,
+		Signatures:  tx.Signatures,
+	}
+	if len(tx.FeePayer) > 0 {
+		feePayer := crypto.AddressFromPubKey(tx.FeePayer)
+		parsedTx.FeePayer = &feePayer
+	}
+	if tx.FeeToken != crypto.EmptyAddress {
+		feeToken := tx.FeeToken
+		parsedTx.FeeToken = &feeToken
+	}
+	if tx.IsScheduled() {
+		parsedTx.Schedule = &schedule{Height: tx.ScheduleHeight, Time: tx.ScheduleTime}
+	}
+
+	if tx.IsMultisigConfig() {
+		parsedTx.Type = transactionTypeMultisig
+		parsedTx.Multisig = &multisig{Threshold: tx.MultisigThreshold}
+		for _, key := range tx.MultisigKeys {
+			parsedTx.Multisig.Keys = append(parsedTx.Multisig.Keys, crypto.AddressFromPubKey(key))
+		}
+		return &parsedTx, nil
+	}
+	if tx.IsRewardConfig() {
+		parsedTx.Type = transactionTypeReward
+		reward := tx.RewardAddress
+		parsedTx.Reward = &reward
+		return &parsedTx, nil
+	}
+	if tx.IsMultiCall() {
+		parsedTx.Type = transactionTypeMultiCall
+		for _, txCall := range tx.Payload.Calls {
+			contract, err := service.loadContract(txCall.Receiver)
+			if err != nil {
+				return nil, err
+			}
+			parsedCall, err := service.parseFunction(txCall.ID, txCall.Args, contract)
+			if err != nil {
+				return nil, err
+			}
+			parsedCall.Contract = txCall.Receiver.String()
+			parsedTx.Calls = append(parsedTx.Calls, *parsedCall)
+		}
+		return &parsedTx, nil
+	}
+	if tx.IsTransfer() {
+		parsedTx.Type = transactionTypeTransfer
+		if tx.Token != crypto.EmptyAddress {
+			token := tx.Token
+			parsedTx.Token = &token
+		}
+		return &parsedTx, nil
 	}
 
 	var contract *abi.Contract
-	if tx.Receiver != crypto.EmptyAddress {
-		parsedTx.Type = transactionTypeInvoke
-		account, err := service.state.GetAccount(tx.Receiver)
-		if err != nil {
-			return nil, err
+	if tx.IsUpgrade() {
+		// Migration is a function of the new code
+		parsedTx.Type = transactionTypeUpgrade
+		c, err := abi.DecodeContract(tx.Payload.Contract)
+		if err == nil {
+			contract = c
 		}
-		c, err := account.GetContract()
+	} else if tx.Receiver != crypto.EmptyAddress {
+		parsedTx.Type = transactionTypeInvoke
+		c, err := service.loadContract(tx.Receiver)
 		if err != nil {
 			return nil, err
 		}
 		contract = c
 	} else {
 		parsedTx.Type = transactionTypeDeploy
-		parsedTx.Receiver = crypto.NewDeploymentAddress(
-			crypto.AddressFromPubKey(tx.Sender.PublicKey),
-			tx.Sender.Nonce,
-		)
+		parsedTx.Receiver = tx.DeploymentAddress()
 		c, err := abi.DecodeContract(tx.Payload.Contract)
 		if err == nil {
 			contract = c
@@ -174,6 +261,10 @@ func (service *Service) parseReceipt(r *crypto.Receipt) (*receipt, error) {
 		Events:      make([]call, 0),
 		PostState:   r.PostState,
 	}
+	if r.FeeToken != crypto.EmptyAddress {
+		feeToken := r.FeeToken
+		parsedReceipt.FeeToken = &feeToken
+	}
 	for _, event := range r.Events {
 		parsedEvent, err := service.parseEvent(event.ID, event.Args, event.Contract)
 		if err != nil {
@@ -181,20 +272,40 @@ func (service *Service) parseReceipt(r *crypto.Receipt) (*receipt, error) {
 		}
 		parsedReceipt.Events = append(parsedReceipt.Events, *parsedEvent)
 	}
+	for _, result := range r.Calls {
+		parsedResult := callResult{
+			Result:  fmt.Sprintf("%x", result.Result),
+			Code:    result.Code,
+			GasUsed: result.GasUsed,
+			Events:  make([]call, 0),
+		}
+		for _, event := range result.Events {
+			parsedEvent, err := service.parseEvent(event.ID, event.Args, event.Contract)
+			if err != nil {
+				return nil, err
+			}
+			parsedResult.Events = append(parsedResult.Events, *parsedEvent)
+		}
+		parsedReceipt.Calls = append(parsedReceipt.Calls, parsedResult)
+	}
 	return &parsedReceipt, nil
 }
 
 func (service *Service) parseBlock(rawBlock *crypto.Block) (*block, error) {
 	parsedBlock := block{
-		Hash:            rawBlock.Hash(),
-		Height:          rawBlock.Height,
-		Time:            rawBlock.Time,
-		Parent:          rawBlock.Parent,
-		StateRoot:       rawBlock.StateRoot,
-		TransactionRoot: rawBlock.TransactionRoot,
-		ReceiptRoot:     rawBlock.ReceiptRoot,
-		Transactions:    []transaction{},
-		Receipts:        []receipt{},
+		Hash:              rawBlock.Hash(),
+		Height:            rawBlock.Height,
+		Time:              rawBlock.Time,
+		Parent:            rawBlock.Parent,
+		StateRoot:         rawBlock.StateRoot,
+		TransactionRoot:   rawBlock.TransactionRoot,
+		ReceiptRoot:       rawBlock.ReceiptRoot,
+		SystemReceiptRoot: rawBlock.SystemReceiptRoot,
+		GasUsed:           rawBlock.GasUsed,
+		BaseFee:           rawBlock.BaseFee,
+		Transactions:      []transaction{},
+		Receipts:          []receipt{},
+		SystemReceipts:    []receipt{},
 	}
 
 	for _, tx := range rawBlock.Transactions() {
@@ -205,23 +316,21 @@ func (service *Service) parseBlock(rawBlock *crypto.Block) (*block, error) {
 		parsedBlock.Transactions = append(parsedBlock.Transactions, *parsedTx)
 	}
 
-	txHashToReceipt := make(map[common.Hash]*receipt)
 	for _, receipt := range rawBlock.Receipts() {
 		parsedReceipt, err := service.parseReceipt(receipt)
-		txHashToReceipt[parsedReceipt.Transaction] = parsedReceipt
 		if err != nil {
 			return nil, err
 		}
 		parsedBlock.Receipts = append(parsedBlock.Receipts, *parsedReceipt)
 	}
 
-	sort.Slice(parsedBlock.Receipts, func(i, j int) bool {
-		return parsedBlock.Receipts[i].Index < parsedBlock.Receipts[j].Index
-	})
-
-	sort.Slice(parsedBlock.Transactions, func(i, j int) bool {
-		return txHashToReceipt[parsedBlock.Transactions[i].Hash].Index < txHashToReceipt[parsedBlock.Transactions[j].Hash].Index
-	})
+	for _, receipt := range rawBlock.SystemReceipts() {
+		parsedReceipt, err := service.parseReceipt(receipt)
+		if err != nil {
+			return nil, err
+		}
+		parsedBlock.SystemReceipts = append(parsedBlock.SystemReceipts, *parsedReceipt)
+	}
 
 	return &parsedBlock, nil
 }
@@ -0,0 +1,66 @@
+package chain
+
+import (
+	"net/http"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// GetScheduledCallsParams is params to GetScheduledCalls
+type GetScheduledCallsParams struct{}
+
+// GetScheduledCallsResult is result of GetScheduledCalls, calls are in execution order
+type GetScheduledCallsResult struct {
+	Calls []scheduledCall `json:"calls"`
+}
+
+// GetScheduledCalls returns calls queued for later heights or times at latest block
+func (service *Service) GetScheduledCalls(r *http.Request, params *GetScheduledCallsParams, result *GetScheduledCallsResult) error {
+	service.syncLatestState()
+	calls, err := service.state.ScheduledCalls()
+	if err != nil {
+		return err
+	}
+	result.Calls = []scheduledCall{}
+	for _, call := range calls {
+		parsedCall, err := service.parseScheduledCall(call)
+		if err != nil {
+			return err
+		}
+		result.Calls = append(result.Calls, *parsedCall)
+	}
+	return nil
+}
+
+func (service *Service) parseScheduledCall(call *crypto.ScheduledCall) (*scheduledCall, error) {
+	parsedCall := scheduledCall{
+		Hash:     call.Hash(),
+		Sequence: call.Sequence,
+		Caller:   call.Caller,
+		Receiver: call.Receiver,
+		Height:   call.Height,
+		Time:     call.Time,
+		GasLimit: call.GasLimit,
+	}
+	account, err := service.state.GetAccount(call.Receiver)
+	if err != nil {
+		return nil, err
+	}
+	// Receiver might be gone before the call is due
+	if account == nil || !account.IsContract() {
+		return &parsedCall, nil
+	}
+	contract, err := account.GetContract()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := contract.Header.GetFunctionByMethodID(call.ID); err != nil {
+		return &parsedCall, nil
+	}
+	payload, err := service.parseFunction(call.ID, call.Args, contract)
+	if err != nil {
+		return nil, err
+	}
+	parsedCall.Payload = *payload
+	return &parsedCall, nil
+}
@@ -3,12 +3,17 @@ package chain
 import (
 	"crypto/ed25519"
 	"encoding/base64"
+	"fmt"
 	"os"
 	"testing"
 
+	"github.com/QuoineFinancial/liquid-chain/abi"
 	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/consensus"
 	"github.com/QuoineFinancial/liquid-chain/crypto"
 	"github.com/QuoineFinancial/liquid-chain/storage"
+	"github.com/QuoineFinancial/liquid-chain/trie"
+	"github.com/QuoineFinancial/liquid-chain/util"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,15 +32,17 @@ func TestGetLatestBlock(t *testing.T) {
 	testResourceInstance.service.GetLatestBlock(nil, &LatestBlockParams{}, &result)
 
 	assert.Equal(t, block{
-		Hash:            common.HexToHash("c3bfeccd3d6ac912c723dc2127101693763e1e31ca28fa53cdb30c69b4e1d051"),
+		Hash:            common.HexToHash("d32ad561debf87fd31381973e9c0188cb8fb11be45379af8cdfcbaf92daca93d"),
 		Height:          4,
 		Time:            4,
-		Parent:          common.HexToHash("2d8dd7ca6b5afdf9dba6470968b17a7cabac443aa87fe6a6468195f28b7a2965"),
-		StateRoot:       common.HexToHash("37919c75f0336a7e2bd96e8551facb7089960f99d09b10ed75808491da53ecf4"),
+		Parent:          common.HexToHash("37d21e96f8e5d2fefa4dd85d6053127e59ebd12dcbaaf0781b56d2fa36f16725"),
+		StateRoot:       common.HexToHash("4ce537264274f7c8a28e2f57be74a1ae84b6fed37ec69ed29cfe4cda92e8b955"),
 		TransactionRoot: common.HexToHash("45b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c0"),
 		ReceiptRoot:     common.HexToHash("45b0cfc220ceec5b7c1c62c4d4193d38e4eba48e8815729ce75f9c0ab0e4c1c0"),
+		BaseFee:         18,
 		Transactions:    []transaction{},
 		Receipts:        []receipt{},
+		SystemReceipts:  []receipt{},
 	}, *result.Block)
 }
 
@@ -55,21 +62,22 @@ func TestGetBlockByHeight(t *testing.T) {
 	}
 
 	signatures := make([][]byte, 3)
-	signatures[0], _ = base64.StdEncoding.DecodeString("OttqA4/C5Bk/04EMSXsBZ8U8bNWb4ErsBwStsdo4gDuV9kKEdb2Z/TEr9WQb100e7gj3g1meyKVinI2ZbjGcBg==")
-	signatures[1], _ = base64.StdEncoding.DecodeString("qSZ39N1f1bOVE9Hhd9dog6iOHYbzXXNBr1fPjdUq5uOqrMbzh65gxPqpHNTZCHNgRRvC2QXLV+OReQqf4R9OCA==")
-	signatures[2], _ = base64.StdEncoding.DecodeString("yyi3QL2b4IHPunS6m+cpc0dxD0k6Rx8frmQfHzc6hNt+gOZG4sBEesz92dGk2tnu/4dm7NNJKAQdBGYJoFPYDQ==")
+	signatures[0], _ = base64.StdEncoding.DecodeString("zP4RRNobXeSKHZvvpU8O2nlGAr1q4liy5tCa1vRLAD7hiu0gI/fQDRfR/UtWFee6bAqdVoH+KaCaJINAM50mCg==")
+	signatures[1], _ = base64.StdEncoding.DecodeString("PylicRFAD+iDgKibu1QoryQWcwFBZxPa6F3sd46Vqc8wN2MYJLLSZ4d4jXkB57gnayyG66c92JLPFYNt5s2HCw==")
+	signatures[2], _ = base64.StdEncoding.DecodeString("mDJ01TxdoesqBMOHDjIHT9/m4/GYt2C95eWrS3YdZ8WgsmFegzdWHjBMkgsowESfOzFe8TTdexT/WE+PbD2+Dw==")
 
 	assert.Equal(t, block{
 		Time:            2,
 		Height:          2,
-		Hash:            common.HexToHash("6ef55f78f482353d673e1429d30c967051ce8b7d47a3602e93d87e686bb5c7b0"),
-		Parent:          common.HexToHash("acb376f46a530ef5c8d7702863d81e7c1f1b1a54f008cdbc7c380a8b8e13339b"),
-		StateRoot:       common.HexToHash("3ec58cab3d13e0eaff2d4e06effb5445b9016324b38aef7f922157c987e5bbf7"),
-		TransactionRoot: common.HexToHash("7c627e647b368cb1911bb95850210034927fb09394ff6be40548febe2db01b3d"),
-		ReceiptRoot:     common.HexToHash("b54d0a78cdcdfba14bcaa39b7d7e2fccb56b594f4748fce3b6a55df9197e0758"),
+		Hash:            common.HexToHash("40f68f95023ba16816502e23c2e7f3664cb47fb581027a883440be28cfcda759"),
+		Parent:          common.HexToHash("14ce82cd2e02fda3816f3fefde2f08ba6f5207a2f086f4e4f9d2cdf8b05a6e06"),
+		StateRoot:       common.HexToHash("4ae965157e7d33f726dedc532946f6d5004386885881a67b62bad1330561a1ef"),
+		TransactionRoot: common.HexToHash("7789a6d6f1493ab2e24b0d202624ef5a6e28890e64b6aabb453762350024c68b"),
+		ReceiptRoot:     common.HexToHash("5c878dd1159c9af074c5bdd4ec4784af89f47d6366b379357dec7b63d5a0b5bb"),
+		BaseFee:         18,
 
 		Transactions: []transaction{{
-			Hash:        common.HexToHash("5e6552f82be4fe44e5f6915ca37ca2de24085da0cc83385040b68ace94b6d213"),
+			Hash:        common.HexToHash("e163f98330d850a09fefdc73665cfc605498e05dba4c4e36124c0ef097a595fc"),
 			Type:        "invoke",
 			BlockHeight: 2,
 			Version:     1,
@@ -88,7 +96,7 @@ func TestGetBlockByHeight(t *testing.T) {
 				}},
 			},
 		}, {
-			Hash:        common.HexToHash("b3fef26e5cb52f0681a06bb9c9ff78acb53ef79daa0c46fecbf1e212e9a67ddc"),
+			Hash:        common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79"),
 			Type:        "invoke",
 			BlockHeight: 2,
 			Version:     1,
@@ -107,7 +115,7 @@ func TestGetBlockByHeight(t *testing.T) {
 				}},
 			},
 		}, {
-			Hash:        common.HexToHash("c253c3e7f7ed6b393ba5a6e2ea73e4218abb3aa0eb5d36065a4367871341784b"),
+			Hash:        common.HexToHash("123ae0adc58288198c7740e33b01f002495756581e5ebf78c276f80ba35a5725"),
 			Type:        "invoke",
 			BlockHeight: 2,
 			Version:     1,
@@ -129,7 +137,7 @@ func TestGetBlockByHeight(t *testing.T) {
 
 		Receipts: []receipt{{
 			Index:       0,
-			Transaction: common.HexToHash("5e6552f82be4fe44e5f6915ca37ca2de24085da0cc83385040b68ace94b6d213"),
+			Transaction: common.HexToHash("e163f98330d850a09fefdc73665cfc605498e05dba4c4e36124c0ef097a595fc"),
 			Result:      "0",
 			GasUsed:     0,
 			Code:        0,
@@ -146,10 +154,10 @@ func TestGetBlockByHeight(t *testing.T) {
 					Value: "1000",
 				}},
 			}},
-			PostState: common.HexToHash("a6324a4017b35eb099ec4768d79e3a968f73510d1a5f38b42fce197d5fc021d7"),
+			PostState: common.HexToHash("c1ec570355f0fda5e8288ddc0271cb2d85a87b4f12641f774ed1ae6870e0826c"),
 		}, {
 			Index:       1,
-			Transaction: common.HexToHash("b3fef26e5cb52f0681a06bb9c9ff78acb53ef79daa0c46fecbf1e212e9a67ddc"),
+			Transaction: common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79"),
 			Result:      "0",
 			GasUsed:     0,
 			Code:        0,
@@ -166,10 +174,10 @@ func TestGetBlockByHeight(t *testing.T) {
 					Value: "1000",
 				}},
 			}},
-			PostState: common.HexToHash("3b214fa485b8125b9221fac1ae38dc24b259759772af8800044dc67caef5979c"),
+			PostState: common.HexToHash("29e8584350f30709b5f867bf195326f5e35294713ca679a711a3791ae1e3c81d"),
 		}, {
 			Index:       2,
-			Transaction: common.HexToHash("c253c3e7f7ed6b393ba5a6e2ea73e4218abb3aa0eb5d36065a4367871341784b"),
+			Transaction: common.HexToHash("123ae0adc58288198c7740e33b01f002495756581e5ebf78c276f80ba35a5725"),
 			Result:      "0",
 			GasUsed:     0,
 			Code:        0,
@@ -186,23 +194,24 @@ func TestGetBlockByHeight(t *testing.T) {
 					Value: "1000",
 				}},
 			}},
-			PostState: common.HexToHash("3ec58cab3d13e0eaff2d4e06effb5445b9016324b38aef7f922157c987e5bbf7"),
+			PostState: common.HexToHash("4ae965157e7d33f726dedc532946f6d5004386885881a67b62bad1330561a1ef"),
 		}},
+		SystemReceipts: []receipt{},
 	}, *result.Block)
 }
 
 func TestGetTransaction(t *testing.T) {
 	var result GetTransactionResult
 	testResourceInstance.service.GetTransaction(nil, &GetTransactionParams{
-		Hash: "b3fef26e5cb52f0681a06bb9c9ff78acb53ef79daa0c46fecbf1e212e9a67ddc",
+		Hash: "a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79",
 	}, &result)
 
 	sender, _ := crypto.AddressFromString("LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT")
-	receiver, _ := crypto.AddressFromString("LBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6XNH")
-	signature, _ := base64.StdEncoding.DecodeString("OttqA4/C5Bk/04EMSXsBZ8U8bNWb4ErsBwStsdo4gDuV9kKEdb2Z/TEr9WQb100e7gj3g1meyKVinI2ZbjGcBg==")
+	receiver, _ := crypto.AddressFromString("CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK")
+	signature, _ := base64.StdEncoding.DecodeString("zP4RRNobXeSKHZvvpU8O2nlGAr1q4liy5tCa1vRLAD7hiu0gI/fQDRfR/UtWFee6bAqdVoH+KaCaJINAM50mCg==")
 
 	assert.Equal(t, transaction{
-		Hash:        common.HexToHash("b3fef26e5cb52f0681a06bb9c9ff78acb53ef79daa0c46fecbf1e212e9a67ddc"),
+		Hash:        common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79"),
 		Type:        "invoke",
 		BlockHeight: 2,
 		Version:     1,
@@ -224,7 +233,7 @@ func TestGetTransaction(t *testing.T) {
 
 	assert.Equal(t, receipt{
 		Index:       1,
-		Transaction: common.HexToHash("b3fef26e5cb52f0681a06bb9c9ff78acb53ef79daa0c46fecbf1e212e9a67ddc"),
+		Transaction: common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79"),
 		Result:      "0",
 		GasUsed:     0,
 		Code:        0,
@@ -241,7 +250,7 @@ func TestGetTransaction(t *testing.T) {
 				Value: "1000",
 			}},
 		}},
-		PostState: common.HexToHash("3b214fa485b8125b9221fac1ae38dc24b259759772af8800044dc67caef5979c"),
+		PostState: common.HexToHash("29e8584350f30709b5f867bf195326f5e35294713ca679a711a3791ae1e3c81d"),
 	}, *result.Receipt)
 }
 
@@ -249,6 +258,37 @@ func newUint64(value uint64) *uint64 {
 	return &value
 }
 
+func TestGetTransactionProof(t *testing.T) {
+	var result GetTransactionProofResult
+	err := testResourceInstance.service.GetTransactionProof(nil, &GetTransactionProofParams{Height: 2, Index: 1}, &result)
+	assert.NoError(t, err)
+	txHash := common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79")
+	assert.Equal(t, txHash, result.Transaction)
+
+	var block BlockResult
+	testResourceInstance.service.GetBlockByHeight(nil, &BlockByHeightParams{Height: 2}, &block)
+	assert.Equal(t, block.Block.Hash, result.Block)
+	assert.Equal(t, block.Block.TransactionRoot, result.TransactionRoot)
+	assert.Equal(t, block.Block.ReceiptRoot, result.ReceiptRoot)
+
+	key := []byte{0, 0, 0, 1}
+	rawTx, err := trie.VerifyProof(result.TransactionRoot, key, result.TransactionProof)
+	assert.NoError(t, err)
+	tx, err := crypto.DecodeTransaction(rawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, tx.Hash())
+
+	rawReceipt, err := trie.VerifyProof(result.ReceiptRoot, key, result.ReceiptProof)
+	assert.NoError(t, err)
+	receipt, err := crypto.DecodeReceipt(rawReceipt)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, receipt.Transaction)
+	assert.Equal(t, uint32(1), receipt.Index)
+
+	err = testResourceInstance.service.GetTransactionProof(nil, &GetTransactionProofParams{Height: 2, Index: 3}, &result)
+	assert.EqualError(t, err, "transaction 3 not found in block 2")
+}
+
 func TestCall(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -259,7 +299,7 @@ func TestCall(t *testing.T) {
 		name: "valid",
 		params: CallParams{
 			Height:  nil,
-			Address: "LBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6XNH",
+			Address: "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK",
 			Method:  "get_balance",
 			Args:    []string{"LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT"},
 		},
@@ -294,7 +334,7 @@ func TestCall(t *testing.T) {
 		name: "invalid function",
 		params: CallParams{
 			Height:  newUint64(1),
-			Address: "LBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6XNH",
+			Address: "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK",
 			Method:  "invalid_function",
 		},
 		wantErr: true,
@@ -302,7 +342,7 @@ func TestCall(t *testing.T) {
 		name: "invalid params",
 		params: CallParams{
 			Height:  newUint64(1),
-			Address: "LBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6XNH",
+			Address: "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK",
 			Method:  "get_balance",
 			Args:    []string{},
 		},
@@ -310,7 +350,7 @@ func TestCall(t *testing.T) {
 	}, {
 		name: "ignite with events",
 		params: CallParams{
-			Address: "LA3K6XGDQXAZN6J22J5VCEFIU25PE4BEZRZE5K76WDGUIRV3HLKJALPV",
+			Address: "CA3K6XGDQXAZN6J22J5VCEFIU25PE4BEZRZE5K76WDGUIRV3HLKJASFY",
 			Method:  "say",
 			Args:    []string{"1"},
 		},
@@ -318,7 +358,7 @@ func TestCall(t *testing.T) {
 			Result: "1",
 			Code:   0,
 			Events: []*call{{
-				Contract: "LA3K6XGDQXAZN6J22J5VCEFIU25PE4BEZRZE5K76WDGUIRV3HLKJALPV",
+				Contract: "CA3K6XGDQXAZN6J22J5VCEFIU25PE4BEZRZE5K76WDGUIRV3HLKJASFY",
 				Name:     "Say",
 				Args: []argument{{
 					Type:  "lparray",
@@ -351,7 +391,7 @@ func TestGetAccount(t *testing.T) {
 	}{{
 		name: "valid",
 		params: GetAccountParams{
-			Address: "LBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6XNH",
+			Address: "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK",
 		},
 		result: GetAccountResult{
 			Account: &storage.Account{
@@ -388,3 +428,227 @@ func TestGetAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBaseFee(t *testing.T) {
+	var result GetBaseFeeResult
+	err := testResourceInstance.service.GetBaseFee(nil, &GetBaseFeeParams{}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, GetBaseFeeResult{
+		Height:      4,
+		BaseFee:     18,
+		GasUsed:     0,
+		NextBaseFee: 18,
+	}, result)
+}
+
+func TestGetGasStation(t *testing.T) {
+	var result GetGasStationResult
+	err := testResourceInstance.service.GetGasStation(nil, &GetGasStationParams{}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, GetGasStationResult{
+		Height:  4,
+		Station: "free",
+		Policy:  "free",
+	}, result)
+}
+
+func TestParseSystemEvent(t *testing.T) {
+	event, _ := consensus.SystemEvents.GetEvent(consensus.GasStationChangedEvent)
+	args, _ := abi.EncodeFromBytes(event.Parameters, [][]byte{{0}, {1}})
+	got, err := testResourceInstance.service.parseEvent(crypto.GetMethodID(consensus.GasStationChangedEvent), args, crypto.EmptyAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, &call{
+		Contract: crypto.EmptyAddress.String(),
+		Name:     consensus.GasStationChangedEvent,
+		Args: []argument{
+			{Type: "uint8", Name: "from", Value: "0"},
+			{Type: "uint8", Name: "to", Value: "1"},
+		},
+	}, got)
+}
+
+func TestParseUnknownMethod(t *testing.T) {
+	methodID := crypto.GetMethodID("removed")
+	got, err := testResourceInstance.service.parseEvent(methodID, []byte{1, 2}, crypto.EmptyAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, &call{
+		Contract: crypto.EmptyAddress.String(),
+		MethodID: fmt.Sprintf("%x", methodID[:]),
+		RawArgs:  "AQI=",
+	}, got)
+
+	got, err = testResourceInstance.service.parseFunction(methodID, []byte{1, 2}, &abi.Contract{Header: &abi.Header{}})
+	assert.NoError(t, err)
+	assert.Equal(t, &call{MethodID: fmt.Sprintf("%x", methodID[:]), RawArgs: "AQI="}, got)
+}
+
+func TestParseDeletedContract(t *testing.T) {
+	seed := make([]byte, 32)
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	deleted := crypto.NewDeploymentAddress(crypto.AddressFromPubKey(privateKey.Public().(ed25519.PublicKey)), 100)
+	methodID := crypto.GetMethodID("transfer")
+
+	got, err := testResourceInstance.service.parseEvent(methodID, []byte{1, 2}, deleted)
+	assert.NoError(t, err)
+	assert.Equal(t, &call{Contract: deleted.String(), MethodID: fmt.Sprintf("%x", methodID[:]), RawArgs: "AQI="}, got)
+
+	tx := &crypto.Transaction{
+		Version:  1,
+		Sender:   &crypto.TxSender{PublicKey: privateKey.Public().(ed25519.PublicKey)},
+		Receiver: deleted,
+		Payload:  &crypto.TxPayload{ID: methodID, Args: []byte{1, 2}},
+		GasPrice: 1,
+	}
+	parsed, err := testResourceInstance.service.parseTransaction(tx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, transactionTypeInvoke, parsed.Type)
+	assert.Equal(t, call{MethodID: fmt.Sprintf("%x", methodID[:]), RawArgs: "AQI="}, parsed.Payload)
+
+	tx.Payload = &crypto.TxPayload{Calls: []*crypto.TxCall{{Receiver: deleted, ID: methodID, Args: []byte{1, 2}}}}
+	parsed, err = testResourceInstance.service.parseTransaction(tx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []call{{Contract: deleted.String(), MethodID: fmt.Sprintf("%x", methodID[:]), RawArgs: "AQI="}}, parsed.Calls)
+}
+
+func TestGetStorageRange(t *testing.T) {
+	address := "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK"
+	var all GetStorageRangeResult
+	err := testResourceInstance.service.GetStorageRange(nil, &GetStorageRangeParams{Address: address}, &all)
+	assert.NoError(t, err)
+	assert.True(t, len(all.Entries) > 1)
+	assert.Nil(t, all.Next)
+
+	// Paging with limit one walks the same entries
+	var paged []storageEntry
+	params := GetStorageRangeParams{Address: address, Limit: 1}
+	for {
+		var page GetStorageRangeResult
+		assert.NoError(t, testResourceInstance.service.GetStorageRange(nil, &params, &page))
+		paged = append(paged, page.Entries...)
+		if page.Next == nil {
+			break
+		}
+		params.Start = page.Next
+	}
+	assert.Equal(t, all.Entries, paged)
+
+	var prefixed GetStorageRangeResult
+	prefix := all.Entries[0].Key
+	err = testResourceInstance.service.GetStorageRange(nil, &GetStorageRangeParams{Address: address, Prefix: prefix}, &prefixed)
+	assert.NoError(t, err)
+	assert.Equal(t, all.Entries[0], prefixed.Entries[0])
+
+	err = testResourceInstance.service.GetStorageRange(nil, &GetStorageRangeParams{Address: "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53"}, &prefixed)
+	assert.Error(t, err)
+}
+
+func TestGetContractHistory(t *testing.T) {
+	var result GetContractHistoryResult
+	err := testResourceInstance.service.GetContractHistory(nil, &GetContractHistoryParams{Address: "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK"}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, GetContractHistoryResult{
+		ContractHash: common.Hash{0xd8, 0x9a, 0xb7, 0x4c, 0xc7, 0xf9, 0x5c, 0x3, 0xd5, 0x7d, 0xc6, 0x76, 0xee, 0xeb, 0x9d, 0xfc, 0x78, 0x15, 0xde, 0xe8, 0xc0, 0x5d, 0x7b, 0x2a, 0xe2, 0x8b, 0x7, 0xee, 0x5f, 0x6a, 0xa1, 0x4}.String(),
+		History:      []string{},
+		Creator:      "LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT",
+	}, result)
+
+	err = testResourceInstance.service.GetContractHistory(nil, &GetContractHistoryParams{Address: "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53"}, &result)
+	assert.Error(t, err)
+}
+
+func TestParseTransferTransaction(t *testing.T) {
+	seed := make([]byte, 32)
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	receiver, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	tx := &crypto.Transaction{
+		Version:  2,
+		Sender:   &crypto.TxSender{PublicKey: privateKey.Public().(ed25519.PublicKey)},
+		Receiver: receiver,
+		Payload:  &crypto.TxPayload{},
+		GasPrice: 1,
+		Value:    100,
+	}
+	parsed, err := testResourceInstance.service.parseTransaction(tx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, transactionTypeTransfer, parsed.Type)
+	assert.Equal(t, receiver, parsed.Receiver)
+	assert.Equal(t, uint64(100), parsed.Value)
+	assert.Nil(t, parsed.Token)
+}
+
+func TestParseUpgradeTransaction(t *testing.T) {
+	seed := make([]byte, 32)
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	receiver, _ := crypto.AddressFromString("CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK")
+	upgrade, err := util.BuildDeployTxPayload("../../test/testdata/liquid-token.wasm", "../../test/testdata/liquid-token-abi.json", "", nil)
+	assert.NoError(t, err)
+	tx := &crypto.Transaction{
+		Version:  2,
+		Sender:   &crypto.TxSender{PublicKey: privateKey.Public().(ed25519.PublicKey)},
+		Receiver: receiver,
+		Payload:  upgrade,
+		GasPrice: 1,
+	}
+	parsed, err := testResourceInstance.service.parseTransaction(tx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, transactionTypeUpgrade, parsed.Type)
+	assert.Equal(t, receiver, parsed.Receiver)
+	assert.Equal(t, call{}, parsed.Payload)
+}
+
+func TestGetScheduledCalls(t *testing.T) {
+	var result GetScheduledCallsResult
+	err := testResourceInstance.service.GetScheduledCalls(nil, &GetScheduledCallsParams{}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, []scheduledCall{}, result.Calls)
+}
+
+func TestGetTransactionsByAddress(t *testing.T) {
+	sender := "LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT"
+	contract := "CBAPQ4LVHFYZQXRSS3CCN6VUZ2EEC6IN5S2RGQLHS3RNNOIBNP4B6OHK"
+	invokeHash := common.HexToHash("a2c84931d7f2d280abe157998aec928bd351d13016ac3cd5f1f86228c6201b79")
+	query := func(params GetTransactionsByAddressParams) GetTransactionsByAddressResult {
+		var result GetTransactionsByAddressResult
+		assert.NoError(t, testResourceInstance.service.GetTransactionsByAddress(nil, &params, &result))
+		return result
+	}
+
+	// Latest first, sender is minted to in event of its invoke
+	result := query(GetTransactionsByAddressParams{Address: sender})
+	assert.Len(t, result.Transactions, 3)
+	assert.Equal(t, uint64(0), result.Next)
+	assert.Equal(t, uint64(3), result.Transactions[0].Transaction.BlockHeight)
+	assert.Equal(t, []string{"sender"}, result.Transactions[0].Roles)
+	assert.Equal(t, invokeHash, result.Transactions[1].Transaction.Hash)
+	assert.Equal(t, invokeHash, result.Transactions[1].Receipt.Transaction)
+	assert.Equal(t, []string{"sender", "event"}, result.Transactions[1].Roles)
+	assert.Equal(t, transactionTypeDeploy, result.Transactions[2].Transaction.Type)
+
+	page := query(GetTransactionsByAddressParams{Address: sender, Limit: 2})
+	assert.Len(t, page.Transactions, 2)
+	assert.Equal(t, uint64(1), page.Next)
+	page = query(GetTransactionsByAddressParams{Address: sender, Before: page.Next})
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, result.Transactions[2], page.Transactions[0])
+	assert.Equal(t, uint64(0), page.Next)
+
+	page = query(GetTransactionsByAddressParams{Address: sender, Role: "event"})
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, invokeHash, page.Transactions[0].Transaction.Hash)
+	assert.Equal(t, uint64(0), page.Next)
+
+	// Role pages by positions in transactions of that role
+	page = query(GetTransactionsByAddressParams{Address: sender, Role: "sender", Limit: 1})
+	assert.Equal(t, result.Transactions[:1], page.Transactions)
+	assert.Equal(t, uint64(2), page.Next)
+	page = query(GetTransactionsByAddressParams{Address: sender, Role: "sender", Before: page.Next})
+	assert.Equal(t, result.Transactions[1:], page.Transactions)
+
+	page = query(GetTransactionsByAddressParams{Address: contract})
+	assert.Len(t, page.Transactions, 1)
+	assert.Equal(t, []string{"receiver"}, page.Transactions[0].Roles)
+
+	var empty GetTransactionsByAddressResult
+	err := testResourceInstance.service.GetTransactionsByAddress(nil, &GetTransactionsByAddressParams{Address: sender, Role: "payer"}, &empty)
+	assert.EqualError(t, err, "unknown role payer")
+}
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// Page sizes of GetStorageRange
+const (
+	defaultStorageRangeLimit = 100
+	maxStorageRangeLimit     = 1000
+)
+
+// GetStorageRangeParams is params to GetStorageRange, keys and values are base64 encoded
+type GetStorageRangeParams struct {
+	Address string `json:"address"`
+	Prefix  []byte `json:"prefix"`
+	Start   []byte `json:"start"`
+	Limit   int    `json:"limit"`
+}
+
+type storageEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// GetStorageRangeResult is result of GetStorageRange, Next is start key of following page
+type GetStorageRangeResult struct {
+	Entries []storageEntry `json:"entries"`
+	Next    []byte         `json:"next,omitempty"`
+}
+
+// GetStorageRange pages through storage of a contract in key order
+func (service *Service) GetStorageRange(r *http.Request, params *GetStorageRangeParams, result *GetStorageRangeResult) error {
+	service.syncLatestState()
+	address, err := crypto.AddressFromString(params.Address)
+	if err != nil {
+		return err
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultStorageRangeLimit
+	}
+	if limit > maxStorageRangeLimit {
+		limit = maxStorageRangeLimit
+	}
+
+	account, err := service.state.GetAccount(address)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return errors.New("account not found")
+	}
+
+	start := params.Start
+	if bytes.Compare(start, params.Prefix) < 0 {
+		start = params.Prefix
+	}
+	result.Entries = []storageEntry{}
+	it := account.StorageIterator(start)
+	for it.Next() && bytes.HasPrefix(it.Key, params.Prefix) {
+		if len(result.Entries) == limit {
+			result.Next = append([]byte{}, it.Key...)
+			break
+		}
+		result.Entries = append(result.Entries, storageEntry{
+			Key:   append([]byte{}, it.Key...),
+			Value: append([]byte{}, it.Value...),
+		})
+	}
+	return it.Err
+}
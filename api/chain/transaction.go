@@ -6,8 +6,6 @@ import (
 	"net/http"
 
 	"github.com/QuoineFinancial/liquid-chain/common"
-	"github.com/QuoineFinancial/liquid-chain/crypto"
-	"github.com/QuoineFinancial/liquid-chain/trie"
 )
 
 // GetTransactionParams contains query height
@@ -28,61 +26,95 @@ func (service *Service) GetTransaction(r *http.Request, params *GetTransactionPa
 		return err
 	}
 
+	parsedTx, parsedReceipt, err := service.getTransaction(common.HexToHash(params.Hash))
+	if err != nil {
+		return err
+	}
+	result.Transaction = parsedTx
+	result.Receipt = parsedReceipt
+	return nil
+}
+
+// getTransaction loads tx by its hash with its receipt from block containing it
+func (service *Service) getTransaction(txHash common.Hash) (*transaction, *receipt, error) {
 	// Get block
-	txHash := common.HexToHash(params.Hash)
 	height, err := service.meta.TxHashToBlockHeight(txHash)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	blockHash := service.meta.BlockHeightToBlockHash(height)
 	if blockHash == common.EmptyHash {
-		return fmt.Errorf("block %d not found", height)
+		return nil, nil, fmt.Errorf("block %d not found", height)
 	}
 
 	block, err := service.block.GetBlock(blockHash)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	// Get tx
-	txTrie, err := trie.New(block.TransactionRoot, service.block)
+	// Get tx and receipt
+	index, err := service.meta.TxHashToIndex(txHash)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	rawTx, err := txTrie.Get(txHash.Bytes())
+	tx, receipt, err := service.block.GetTransactionWithReceipt(block, index)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	tx, err := crypto.DecodeTransaction(rawTx)
+	parsedTx, err := service.parseTransaction(tx, height)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	parsedTx, err := service.parseTransaction(tx, height)
+	parsedReceipt, err := service.parseReceipt(receipt)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	return parsedTx, parsedReceipt, nil
+}
 
-	result.Transaction = parsedTx
+// GetTransactionProofParams locates tx by height of its block and its index in block
+type GetTransactionProofParams struct {
+	Height uint64 `json:"height"`
+	Index  uint32 `json:"index"`
+}
 
-	// Get receipt
-	receiptHash := service.meta.TxHashToReceiptHash(txHash)
-	receiptTrie, err := trie.New(block.ReceiptRoot, service.block)
-	if err != nil {
-		return err
+// GetTransactionProofResult is response of GetTransactionProof, proofs are verified against
+// transaction and receipt roots with 4 bytes big endian index as key
+type GetTransactionProofResult struct {
+	Block            common.Hash `json:"block"`
+	Transaction      common.Hash `json:"transaction"`
+	TransactionRoot  common.Hash `json:"transactionRoot"`
+	ReceiptRoot      common.Hash `json:"receiptRoot"`
+	TransactionProof [][]byte    `json:"transactionProof"`
+	ReceiptProof     [][]byte    `json:"receiptProof"`
+}
+
+// GetTransactionProof returns Merkle proofs of tx and its receipt at index of block at height
+func (service *Service) GetTransactionProof(r *http.Request, params *GetTransactionProofParams, result *GetTransactionProofResult) error {
+	service.syncLatestState()
+	blockHash := service.meta.BlockHeightToBlockHash(params.Height)
+	if blockHash == common.EmptyHash {
+		return fmt.Errorf("block %d not found", params.Height)
 	}
-	receiptBytes, err := receiptTrie.Get(receiptHash.Bytes())
+	block, err := service.block.GetBlock(blockHash)
 	if err != nil {
 		return err
 	}
-	receipt, err := crypto.DecodeReceipt(receiptBytes)
+
+	tx, _, err := service.block.GetTransactionWithReceipt(block, params.Index)
 	if err != nil {
 		return err
 	}
-	parsedReceipt, err := service.parseReceipt(receipt)
+	txProof, receiptProof, err := service.block.ProveTransaction(block, params.Index)
 	if err != nil {
 		return err
 	}
-	result.Receipt = parsedReceipt
 
+	result.Block = blockHash
+	result.Transaction = tx.Hash()
+	result.TransactionRoot = block.TransactionRoot
+	result.ReceiptRoot = block.ReceiptRoot
+	result.TransactionProof = txProof
+	result.ReceiptProof = receiptProof
 	return nil
 }
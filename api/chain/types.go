@@ -15,6 +15,8 @@ type call struct {
 	Contract string     `json:"contract,omitempty"`
 	Name     string     `json:"name,omitempty"`
 	Args     []argument `json:"args,omitempty"`
+	MethodID string     `json:"methodId,omitempty"`
+	RawArgs  string     `json:"rawArgs,omitempty"`
 }
 
 type receipt struct {
@@ -25,13 +27,27 @@ type receipt struct {
 	Code        crypto.ReceiptCode `json:"code"`
 	Events      []call             `json:"events"`
 	PostState   common.Hash        `json:"postState"`
+	FeeToken    *crypto.Address    `json:"feeToken,omitempty"`
+	Calls       []callResult       `json:"calls,omitempty"`
+}
+
+type callResult struct {
+	Result  string             `json:"result"`
+	GasUsed uint32             `json:"gasUsed"`
+	Code    crypto.ReceiptCode `json:"code"`
+	Events  []call             `json:"events"`
 }
 
 type transactionType string
 
 const (
-	transactionTypeDeploy transactionType = "deploy"
-	transactionTypeInvoke transactionType = "invoke"
+	transactionTypeDeploy    transactionType = "deploy"
+	transactionTypeInvoke    transactionType = "invoke"
+	transactionTypeUpgrade   transactionType = "upgrade"
+	transactionTypeTransfer  transactionType = "transfer"
+	transactionTypeMultisig  transactionType = "multisig"
+	transactionTypeMultiCall transactionType = "multicall"
+	transactionTypeReward    transactionType = "reward"
 )
 
 type transaction struct {
@@ -46,16 +62,52 @@ type transaction struct {
 	GasPrice    uint32          `json:"gasPrice"`
 	GasLimit    uint32          `json:"gasLimit"`
 	Signature   []byte          `json:"signature"`
+	FeePayer    *crypto.Address `json:"feePayer,omitempty"`
+	FeeToken    *crypto.Address `json:"feeToken,omitempty"`
+	Value       uint64          `json:"value,omitempty"`
+	Token       *crypto.Address `json:"token,omitempty"`
+	ChainID     string          `json:"chainId,omitempty"`
+	Expiry      uint64          `json:"expiry,omitempty"`
+	Signatures  [][]byte        `json:"signatures,omitempty"`
+	Multisig    *multisig       `json:"multisig,omitempty"`
+	Calls       []call          `json:"calls,omitempty"`
+	Schedule    *schedule       `json:"schedule,omitempty"`
+	Reward      *crypto.Address `json:"rewardAddress,omitempty"`
+}
+
+type schedule struct {
+	Height uint64 `json:"height,omitempty"`
+	Time   uint64 `json:"time,omitempty"`
+}
+
+type scheduledCall struct {
+	Hash     common.Hash    `json:"hash"`
+	Sequence uint64         `json:"sequence"`
+	Caller   crypto.Address `json:"caller"`
+	Receiver crypto.Address `json:"receiver"`
+	Payload  call           `json:"payload"`
+	Height   uint64         `json:"height,omitempty"`
+	Time     uint64         `json:"time,omitempty"`
+	GasLimit uint32         `json:"gasLimit"`
+}
+
+type multisig struct {
+	Keys      []crypto.Address `json:"keys"`
+	Threshold uint64           `json:"threshold"`
 }
 
 type block struct {
-	Hash            common.Hash   `json:"hash"`
-	Transactions    []transaction `json:"transactions"`
-	Receipts        []receipt     `json:"receipts"`
-	Height          uint64        `json:"height"`
-	Time            uint64        `json:"time"`
-	Parent          common.Hash   `json:"parent"`
-	StateRoot       common.Hash   `json:"stateRoot"`
-	TransactionRoot common.Hash   `json:"transactionRoot"`
-	ReceiptRoot     common.Hash   `json:"receiptRoot"`
+	Hash              common.Hash   `json:"hash"`
+	Transactions      []transaction `json:"transactions"`
+	Receipts          []receipt     `json:"receipts"`
+	SystemReceipts    []receipt     `json:"systemReceipts"`
+	Height            uint64        `json:"height"`
+	Time              uint64        `json:"time"`
+	Parent            common.Hash   `json:"parent"`
+	StateRoot         common.Hash   `json:"stateRoot"`
+	TransactionRoot   common.Hash   `json:"transactionRoot"`
+	ReceiptRoot       common.Hash   `json:"receiptRoot"`
+	SystemReceiptRoot common.Hash   `json:"systemReceiptRoot"`
+	GasUsed           uint64        `json:"gasUsed"`
+	BaseFee           uint32        `json:"baseFee"`
 }
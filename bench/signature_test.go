@@ -0,0 +1,49 @@
+package bench
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+func signatureChecks(n int) []crypto.SignatureCheck {
+	checks := make([]crypto.SignatureCheck, n)
+	for i := range checks {
+		_, privateKey, _ := ed25519.GenerateKey(nil)
+		message := randomBytes(32)
+		checks[i] = crypto.SignatureCheck{
+			KeyType:   crypto.KeyTypeEd25519,
+			PublicKey: privateKey.Public().(ed25519.PublicKey),
+			Message:   message,
+			Signature: crypto.Sign(privateKey, message),
+		}
+	}
+	return checks
+}
+
+func benchmarkVerify(n int, b *testing.B) {
+	checks := signatureChecks(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, check := range checks {
+			check.Verify()
+		}
+	}
+}
+
+func benchmarkVerifyParallel(n int, b *testing.B) {
+	checks := signatureChecks(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		crypto.VerifyParallel(checks)
+	}
+}
+
+func BenchmarkVerify1(b *testing.B)    { benchmarkVerify(1, b) }
+func BenchmarkVerify100(b *testing.B)  { benchmarkVerify(100, b) }
+func BenchmarkVerify1000(b *testing.B) { benchmarkVerify(1000, b) }
+
+func BenchmarkVerifyParallel1(b *testing.B)    { benchmarkVerifyParallel(1, b) }
+func BenchmarkVerifyParallel100(b *testing.B)  { benchmarkVerifyParallel(100, b) }
+func BenchmarkVerifyParallel1000(b *testing.B) { benchmarkVerifyParallel(1000, b) }
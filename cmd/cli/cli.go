@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,7 +41,7 @@ func broadcast(endpoint string, hexTx []byte) {
 	}
 }
 
-func loadPrivateKey(path string) ed25519.PrivateKey {
+func loadSeed(path string) []byte {
 	dat, err := ioutil.ReadFile(path)
 	if err != nil {
 		panic(err)
@@ -51,7 +52,147 @@ func loadPrivateKey(path string) ed25519.PrivateKey {
 	if err != nil {
 		panic(err)
 	}
-	return ed25519.NewKeyFromSeed(parsed)
+	return parsed
+}
+
+func loadPrivateKey(path string) ed25519.PrivateKey {
+	return ed25519.NewKeyFromSeed(loadSeed(path))
+}
+
+// setVersion raises tx version to the one introducing an optional field in use
+func setVersion(tx *crypto.Transaction, version uint16) {
+	if tx.Version < version {
+		tx.Version = version
+	}
+}
+
+// multisigKeys returns keys given by addresses in keys flag, sorted as multisig account stores them
+func multisigKeys(cmd *cobra.Command) []ed25519.PublicKey {
+	addresses, err := cmd.Root().Flags().GetStringSlice("keys")
+	if err != nil {
+		panic(err)
+	}
+	var keys []ed25519.PublicKey
+	for _, address := range addresses {
+		parsed, err := crypto.AddressFromString(address)
+		if err != nil {
+			panic(err)
+		}
+		key, err := parsed.PubKey()
+		if err != nil {
+			panic(err)
+		}
+		keys = append(keys, key)
+	}
+	return crypto.SortKeys(keys)
+}
+
+// addSignature puts signature of privateKey at position of its key among keys of multisig account
+func addSignature(tx *crypto.Transaction, privateKey ed25519.PrivateKey, keys []ed25519.PublicKey) {
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	for i, key := range keys {
+		if bytes.Equal(key, publicKey) {
+			for len(tx.Signatures) < len(keys) {
+				tx.Signatures = append(tx.Signatures, nil)
+			}
+			dataToSign := crypto.GetSigHash(tx)
+			tx.Signatures[i] = crypto.Sign(privateKey, dataToSign[:])
+			return
+		}
+	}
+	panic("seed is not a key of multisig account")
+}
+
+// sign signs tx by sender, and by fee payer if its seed is given. Value, fee token, chain ID and expiry are set before signing.
+// Tx of multisig account gets the first of its signatures, others are added offline by cosign.
+// With secp256k1 key type, seed is used as secp256k1 private key of sender.
+func sign(cmd *cobra.Command, tx *crypto.Transaction, privateKey ed25519.PrivateKey) {
+	payerSeedPath, err := cmd.Root().Flags().GetString("payer")
+	if err != nil {
+		panic(err)
+	}
+	feeToken, err := cmd.Root().Flags().GetString("fee-token")
+	if err != nil {
+		panic(err)
+	}
+	value, err := cmd.Root().Flags().GetUint64("value")
+	if err != nil {
+		panic(err)
+	}
+	if value > 0 {
+		setVersion(tx, 2)
+		tx.Value = value
+	}
+	if len(feeToken) > 0 {
+		setVersion(tx, 2)
+		if tx.FeeToken, err = crypto.AddressFromString(feeToken); err != nil {
+			panic(err)
+		}
+	}
+	var payerKey ed25519.PrivateKey
+	if len(payerSeedPath) > 0 {
+		payerKey = loadPrivateKey(payerSeedPath)
+		setVersion(tx, 2)
+		tx.FeePayer = payerKey.Public().(ed25519.PublicKey)
+	}
+	chainID, err := cmd.Root().Flags().GetString("chain-id")
+	if err != nil {
+		panic(err)
+	}
+	expiry, err := cmd.Root().Flags().GetUint64("expiry")
+	if err != nil {
+		panic(err)
+	}
+	if len(chainID) > 0 || expiry > 0 {
+		setVersion(tx, 3)
+		tx.ChainID = chainID
+		tx.Expiry = expiry
+	}
+	multisig, err := cmd.Root().Flags().GetString("multisig")
+	if err != nil {
+		panic(err)
+	}
+	if len(multisig) > 0 {
+		setVersion(tx, 3)
+		if tx.Multisig, err = crypto.AddressFromString(multisig); err != nil {
+			panic(err)
+		}
+		tx.Sender.PublicKey = nil
+	}
+
+	keyType, err := cmd.Root().Flags().GetString("key-type")
+	if err != nil {
+		panic(err)
+	}
+	var secp256k1Key []byte
+	switch keyType {
+	case "ed25519":
+	case "secp256k1":
+		seedPath, err := cmd.Root().Flags().GetString("seed")
+		if err != nil {
+			panic(err)
+		}
+		secp256k1Key = loadSeed(seedPath)
+		setVersion(tx, 3)
+		tx.Sender.PublicKey = crypto.Secp256k1PublicKey(secp256k1Key)
+		tx.Sender.KeyType = crypto.KeyTypeSecp256k1
+	default:
+		panic("unsupported key type " + keyType)
+	}
+
+	dataToSign := crypto.GetSigHash(tx)
+	if tx.Multisig != crypto.EmptyAddress {
+		addSignature(tx, privateKey, multisigKeys(cmd))
+	} else if secp256k1Key != nil {
+		if tx.Signature, err = crypto.SignSecp256k1(secp256k1Key, dataToSign[:]); err != nil {
+			panic(err)
+		}
+	} else {
+		tx.Signature = crypto.Sign(privateKey, dataToSign[:])
+	}
+	if payerKey != nil {
+		tx.FeePayerSignature = crypto.Sign(payerKey, dataToSign[:])
+	}
 }
 
 func deploy(cmd *cobra.Command, args []string) {
@@ -75,8 +216,29 @@ func deploy(cmd *cobra.Command, args []string) {
 		GasPrice:  price,
 		Signature: nil,
 	}
-	dataToSign := crypto.GetSigHash(tx)
-	tx.Signature = crypto.Sign(privateKey, dataToSign[:])
+	admin, err := cmd.Flags().GetString("admin")
+	if err != nil {
+		panic(err)
+	}
+	if len(admin) > 0 {
+		setVersion(tx, 2)
+		if tx.Admin, err = crypto.AddressFromString(admin); err != nil {
+			panic(err)
+		}
+	}
+	salt, err := cmd.Flags().GetString("salt")
+	if err != nil {
+		panic(err)
+	}
+	if len(salt) > 0 {
+		setVersion(tx, 3)
+		if tx.Salt, err = hex.DecodeString(salt); err != nil {
+			panic(err)
+		}
+	}
+	sign(cmd, tx, privateKey)
+	contractAddress := tx.DeploymentAddress()
+	log.Printf("Contract address: %s", contractAddress.String())
 
 	if rawTx, err := tx.Encode(); err != nil {
 		panic(err)
@@ -110,8 +272,61 @@ func invoke(cmd *cobra.Command, args []string) {
 		GasPrice:  price,
 		Signature: nil,
 	}
-	dataToSign := crypto.GetSigHash(tx)
-	tx.Signature = crypto.Sign(privateKey, dataToSign[:])
+	if tx.ScheduleHeight, err = cmd.Flags().GetUint64("schedule-height"); err != nil {
+		panic(err)
+	}
+	if tx.ScheduleTime, err = cmd.Flags().GetUint64("schedule-time"); err != nil {
+		panic(err)
+	}
+	if tx.IsScheduled() {
+		setVersion(tx, 3)
+	}
+	sign(cmd, tx, privateKey)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+// multiCall sends calls given as comma separated address, abi path, function and params in one transaction
+func multiCall(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, nonce, gas, price, _ := parseFlags(cmd)
+	privateKey := loadPrivateKey(seedPath)
+
+	payload := &crypto.TxPayload{}
+	for _, arg := range args {
+		parts := strings.Split(arg, ",")
+		if len(parts) < 3 {
+			panic("call requires address, abi path and function: " + arg)
+		}
+		receiver, err := crypto.AddressFromString(parts[0])
+		if err != nil {
+			panic(err)
+		}
+		callPayload, err := util.BuildInvokeTxPayload(parts[1], parts[2], parts[3:])
+		if err != nil {
+			panic(err)
+		}
+		payload.Calls = append(payload.Calls, &crypto.TxCall{
+			Receiver: receiver,
+			ID:       callPayload.ID,
+			Args:     callPayload.Args,
+		})
+	}
+	tx := &crypto.Transaction{
+		Version: 3,
+		Payload: payload,
+		Sender: &crypto.TxSender{
+			Nonce:     uint64(nonce),
+			PublicKey: privateKey.Public().(ed25519.PublicKey),
+		},
+		GasLimit:  gas,
+		GasPrice:  price,
+		Signature: nil,
+	}
+	sign(cmd, tx, privateKey)
 
 	if rawTx, err := tx.Encode(); err != nil {
 		panic(err)
@@ -120,6 +335,219 @@ func invoke(cmd *cobra.Command, args []string) {
 	}
 }
 
+func upgrade(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, nonce, gas, price, _ := parseFlags(cmd)
+	privateKey := loadPrivateKey(seedPath)
+
+	receiver, err := crypto.AddressFromString(args[0])
+	if err != nil {
+		panic(err)
+	}
+
+	payload, err := util.BuildDeployTxPayload(args[1], args[2], consensus.MigrateFunctionName, args[3:])
+	if err != nil {
+		panic(err)
+	}
+	tx := &crypto.Transaction{
+		Version: 1,
+		Payload: payload,
+		Sender: &crypto.TxSender{
+			Nonce:     uint64(nonce),
+			PublicKey: privateKey.Public().(ed25519.PublicKey),
+		},
+		Receiver:  receiver,
+		GasLimit:  gas,
+		GasPrice:  price,
+		Signature: nil,
+	}
+	sign(cmd, tx, privateKey)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+func transfer(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, nonce, gas, price, _ := parseFlags(cmd)
+	privateKey := loadPrivateKey(seedPath)
+
+	receiver, err := crypto.AddressFromString(args[0])
+	if err != nil {
+		panic(err)
+	}
+	value, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	tx := &crypto.Transaction{
+		Version: 2,
+		Payload: &crypto.TxPayload{},
+		Sender: &crypto.TxSender{
+			Nonce:     uint64(nonce),
+			PublicKey: privateKey.Public().(ed25519.PublicKey),
+		},
+		Receiver:  receiver,
+		GasLimit:  gas,
+		GasPrice:  price,
+		Signature: nil,
+		Value:     value,
+	}
+	token, err := cmd.Flags().GetString("token")
+	if err != nil {
+		panic(err)
+	}
+	if len(token) > 0 {
+		if tx.Token, err = crypto.AddressFromString(token); err != nil {
+			panic(err)
+		}
+	}
+	sign(cmd, tx, privateKey)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+// setRewardAddress is sent by seed of validator consensus key, version 3 tx needs chain ID flag
+func setRewardAddress(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, nonce, gas, price, _ := parseFlags(cmd)
+	privateKey := loadPrivateKey(seedPath)
+
+	rewardAddress, err := crypto.AddressFromString(args[0])
+	if err != nil {
+		panic(err)
+	}
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	tx := &crypto.Transaction{
+		Version: 3,
+		Payload: &crypto.TxPayload{},
+		Sender: &crypto.TxSender{
+			Nonce:     uint64(nonce),
+			PublicKey: publicKey,
+		},
+		Receiver:      crypto.AddressFromPubKey(publicKey),
+		GasLimit:      gas,
+		GasPrice:      price,
+		RewardAddress: rewardAddress,
+	}
+	sign(cmd, tx, privateKey)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+func configureMultisig(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, nonce, gas, price, _ := parseFlags(cmd)
+
+	threshold, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	var keys []ed25519.PublicKey
+	for _, address := range args[1:] {
+		parsed, err := crypto.AddressFromString(address)
+		if err != nil {
+			panic(err)
+		}
+		key, err := parsed.PubKey()
+		if err != nil {
+			panic(err)
+		}
+		keys = append(keys, key)
+	}
+	keys = crypto.SortKeys(keys)
+	address := crypto.NewMultisigAddress(keys, threshold)
+	log.Printf("Multisig address: %s", address.String())
+	if len(seedPath) == 0 {
+		return
+	}
+
+	tx := &crypto.Transaction{
+		Version:           3,
+		Payload:           &crypto.TxPayload{},
+		Sender:            &crypto.TxSender{Nonce: uint64(nonce)},
+		Receiver:          address,
+		GasLimit:          gas,
+		GasPrice:          price,
+		Multisig:          address,
+		MultisigKeys:      keys,
+		MultisigThreshold: threshold,
+	}
+	// Rotation is signed by current keys given in keys flag, first configuration by the new ones
+	signingKeys := multisigKeys(cmd)
+	if len(signingKeys) == 0 {
+		signingKeys = keys
+	}
+	addSignature(tx, loadPrivateKey(seedPath), signingKeys)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+func cosign(cmd *cobra.Command, args []string) {
+	seedPath, endpoint, _, _, _, _ := parseFlags(cmd)
+	rawTx, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		panic(err)
+	}
+	tx, err := crypto.DecodeTransaction(rawTx)
+	if err != nil {
+		panic(err)
+	}
+	keys := multisigKeys(cmd)
+	if len(keys) == 0 {
+		keys = tx.MultisigKeys
+	}
+	addSignature(tx, loadPrivateKey(seedPath), keys)
+
+	if rawTx, err := tx.Encode(); err != nil {
+		panic(err)
+	} else {
+		broadcast(endpoint, rawTx)
+	}
+}
+
+func decode(cmd *cobra.Command, args []string) {
+	rawTx, err := base64.StdEncoding.DecodeString(args[0])
+	if err != nil {
+		panic(err)
+	}
+	tx, err := crypto.DecodeTransaction(rawTx)
+	if err != nil {
+		panic(err)
+	}
+	txType := "invoke"
+	if tx.IsMultisigConfig() {
+		txType = "multisig"
+	} else if tx.IsRewardConfig() {
+		txType = "reward"
+	} else if tx.IsMultiCall() {
+		txType = "multicall"
+	} else if tx.IsTransfer() {
+		txType = "transfer"
+	} else if tx.Receiver == crypto.EmptyAddress {
+		txType = "deploy"
+	}
+	decoded, err := json.MarshalIndent(struct {
+		Type string `json:"type"`
+		*crypto.Transaction
+	}{txType, tx}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	log.Println(string(decoded))
+}
+
 func call(cmd *cobra.Command, args []string) {
 	_, endpoint, _, _, _, height := parseFlags(cmd)
 
@@ -132,6 +560,18 @@ func call(cmd *cobra.Command, args []string) {
 	log.Printf("Return: %v", result)
 }
 
+func scheduled(cmd *cobra.Command, args []string) {
+	_, endpoint, _, _, _, _ := parseFlags(cmd)
+
+	var result chain.GetScheduledCallsResult
+	postJSON(endpoint, "chain.GetScheduledCalls", chain.GetScheduledCallsParams{}, &result)
+	decoded, err := json.MarshalIndent(result.Calls, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	log.Println(string(decoded))
+}
+
 func main() {
 	var cmdDeploy = &cobra.Command{
 		Use:   "deploy [path to wasm] [path to contract abi json file]",
@@ -147,6 +587,69 @@ func main() {
 		Run:   invoke,
 	}
 
+	var cmdMultiCall = &cobra.Command{
+		Use:   "multicall [address,path to contract abi json file,function,params]...",
+		Short: "Invoke smart contracts in order within one transaction, all calls are reverted if any fails",
+		Args:  cobra.MinimumNArgs(1),
+		Run:   multiCall,
+	}
+
+	cmdInvoke.Flags().Uint64("schedule-height", 0, "Queue invocation to run at height, gas limit is paid now")
+	cmdInvoke.Flags().Uint64("schedule-time", 0, "Queue invocation to run at unix time, gas limit is paid now")
+
+	var cmdScheduled = &cobra.Command{
+		Use:   "scheduled",
+		Short: "List calls queued for later heights or times",
+		Args:  cobra.NoArgs,
+		Run:   scheduled,
+	}
+
+	cmdDeploy.Flags().String("admin", "", "Address allowed to upgrade contract instead of creator")
+	cmdDeploy.Flags().String("salt", "", "Hex salt deriving contract address from creator and code instead of nonce")
+
+	var cmdUpgrade = &cobra.Command{
+		Use:   "upgrade [address] [path to wasm] [path to contract abi json file] [migrate params]",
+		Short: "Upgrade code of a smart contract keeping its storage",
+		Args:  cobra.MinimumNArgs(3),
+		Run:   upgrade,
+	}
+
+	var cmdTransfer = &cobra.Command{
+		Use:   "transfer [address] [amount]",
+		Short: "Transfer native balance or gas contract token",
+		Args:  cobra.ExactArgs(2),
+		Run:   transfer,
+	}
+	cmdTransfer.Flags().String("token", "", "Address of gas contract token, native balance is sent if empty")
+
+	var cmdReward = &cobra.Command{
+		Use:   "reward [address]",
+		Short: "Set address receiving fees of validator whose consensus key is the seed",
+		Args:  cobra.ExactArgs(1),
+		Run:   setRewardAddress,
+	}
+
+	var cmdMultisig = &cobra.Command{
+		Use:   "multisig [threshold] [key addresses]",
+		Short: "Print multisig address, and configure the account with first signature if seed is given",
+		Args:  cobra.MinimumNArgs(2),
+		Run:   configureMultisig,
+	}
+
+	var cmdCosign = &cobra.Command{
+		Use:   "cosign [base64 encoded transaction]",
+		Short: "Add signature of a multisig key to transaction, it is broadcast if endpoint is given",
+		Args:  cobra.ExactArgs(1),
+		Run:   cosign,
+	}
+
+	var cmdDecode = &cobra.Command{
+		Use:   "decode [base64 encoded transaction]",
+		Short: "Decode a transaction",
+		Args:  cobra.ExactArgs(1),
+		Run:   decode,
+	}
+
 	var cmdCall = &cobra.Command{
 		Use:   "call [address] [function] [params]",
 		Short: "Call a smart contract (read-only)",
@@ -155,10 +658,18 @@ func main() {
 	}
 
 	var rootCmd = &cobra.Command{Use: "app"}
-	rootCmd.AddCommand(cmdDeploy, cmdInvoke, cmdCall)
+	rootCmd.AddCommand(cmdDeploy, cmdInvoke, cmdMultiCall, cmdUpgrade, cmdTransfer, cmdReward, cmdMultisig, cmdCosign, cmdDecode, cmdCall, cmdScheduled)
 	rootCmd.PersistentFlags().StringP("endpoint", "e", "", "Vertex node API endpoint")
 	rootCmd.PersistentFlags().Uint32P("gas", "g", 100000, "Gas limit")
 	rootCmd.PersistentFlags().StringP("seed", "s", "", "Path to seed")
+	rootCmd.PersistentFlags().String("key-type", "ed25519", "Key type of seed, ed25519 or secp256k1")
+	rootCmd.PersistentFlags().String("payer", "", "Path to seed of fee payer")
+	rootCmd.PersistentFlags().String("fee-token", "", "Address of token paying fee")
+	rootCmd.PersistentFlags().Uint64("value", 0, "Native balance sent to receiver")
+	rootCmd.PersistentFlags().String("chain-id", "", "Chain ID bound to signature")
+	rootCmd.PersistentFlags().Uint64("expiry", 0, "Last height accepting transaction")
+	rootCmd.PersistentFlags().String("multisig", "", "Multisig account sending transaction, seed gives one of its signatures")
+	rootCmd.PersistentFlags().StringSlice("keys", nil, "Addresses of keys of multisig account")
 	rootCmd.PersistentFlags().Uint64P("nonce", "n", 0, "Position of transaction")
 	rootCmd.PersistentFlags().Int64("height", 0, "Call the method at height")
 	rootCmd.PersistentFlags().Uint32P("price", "p", 1, "Gas price")
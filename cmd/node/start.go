@@ -68,6 +68,17 @@ func (node *LiquidNode) StartTendermintNode(conf *config.Config) error {
 		return fmt.Errorf("Failed to create node: %v", err)
 	}
 	node.tmNode = n
+	node.app.SetBlockSource(func(height int64) [][]byte {
+		block := n.BlockStore().LoadBlock(height)
+		if block == nil {
+			return nil
+		}
+		rawTxs := make([][]byte, len(block.Txs))
+		for i, tx := range block.Txs {
+			rawTxs[i] = tx
+		}
+		return rawTxs
+	})
 
 	// Stop upon receiving SIGTERM or CTRL-C.
 	tmos.TrapSignal(logger, func() {
@@ -0,0 +1,93 @@
+package consensus
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+// indexAddresses stores transactions of block by addresses they involve, in execution order
+func (app *App) indexAddresses(block *crypto.Block) {
+	headers := make(map[crypto.Address]*abi.Header)
+	receipts := block.Receipts()
+	for i, tx := range block.Transactions() {
+		roles := app.transactionAddresses(tx, receipts[i], headers)
+		addresses := make([]crypto.Address, 0, len(roles))
+		for address := range roles {
+			addresses = append(addresses, address)
+		}
+		sort.Slice(addresses, func(i, j int) bool {
+			return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+		})
+		for _, address := range addresses {
+			app.Meta.StoreAddressTransaction(address, block.Height, uint32(i), tx.Hash(), roles[address])
+		}
+	}
+}
+
+// transactionAddresses returns addresses involved in tx with their roles: its sender, receivers of tx and
+// its calls, and address parameters of events it emits. Events of contracts destructed since are skipped.
+func (app *App) transactionAddresses(tx *crypto.Transaction, receipt *crypto.Receipt, headers map[crypto.Address]*abi.Header) map[crypto.Address]storage.AddressRole {
+	roles := make(map[crypto.Address]storage.AddressRole)
+	roles[tx.SenderAddress()] |= storage.AddressRoleSender
+	if tx.Receiver != crypto.EmptyAddress {
+		roles[tx.Receiver] |= storage.AddressRoleReceiver
+	}
+	if tx.Payload != nil {
+		for _, call := range tx.Payload.Calls {
+			roles[call.Receiver] |= storage.AddressRoleReceiver
+		}
+	}
+
+	events := append([]*crypto.Event{}, receipt.Events...)
+	for _, result := range receipt.Calls {
+		events = append(events, result.Events...)
+	}
+	for _, event := range events {
+		for _, address := range app.eventAddresses(event, headers) {
+			roles[address] |= storage.AddressRoleEvent
+		}
+	}
+	delete(roles, crypto.EmptyAddress)
+	return roles
+}
+
+// eventAddresses decodes address parameters of event with header of its contract
+func (app *App) eventAddresses(event *crypto.Event, headers map[crypto.Address]*abi.Header) []crypto.Address {
+	header, ok := headers[event.Contract]
+	if !ok {
+		if event.Contract == crypto.EmptyAddress {
+			header = SystemEvents
+		} else if account, err := app.State.LoadAccount(event.Contract); err == nil && account != nil && account.IsContract() {
+			if contract, err := account.GetContract(); err == nil {
+				header = contract.Header
+			}
+		}
+		headers[event.Contract] = header
+	}
+	if header == nil {
+		return nil
+	}
+	eventHeader, ok := header.Events[event.ID]
+	if !ok {
+		return nil
+	}
+	args, err := abi.DecodeToBytes(eventHeader.Parameters, event.Args)
+	if err != nil {
+		return nil
+	}
+
+	var addresses []crypto.Address
+	for i, param := range eventHeader.Parameters {
+		if param.Type != abi.Address || param.IsArray || len(args[i]) != crypto.AddressLength {
+			continue
+		}
+		if address, err := crypto.AddressFromBytes(args[i]); err == nil {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,74 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_TransactionAddresses(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, _ := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	first := crypto.NewDeploymentAddress(senderAddress, 0)
+	second := crypto.NewDeploymentAddress(senderAddress, 1)
+	amount := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amount, 10)
+
+	tx := &crypto.Transaction{
+		Version: 3,
+		Sender:  &sender,
+		Payload: &crypto.TxPayload{Calls: []*crypto.TxCall{{Receiver: first}, {Receiver: second}}},
+	}
+	receipt := &crypto.Receipt{
+		Calls: []*crypto.CallResult{{}, {
+			Events: []*crypto.Event{newSystemEvent(GenesisBalanceCreditedEvent, senderAddress[:], amount)},
+		}},
+		// Events of unknown contract are skipped
+		Events: []*crypto.Event{{ID: crypto.GetMethodID("Transfer"), Contract: second}},
+	}
+	roles := app.transactionAddresses(tx, receipt, make(map[crypto.Address]*abi.Header))
+	assert.Equal(t, map[crypto.Address]storage.AddressRole{
+		senderAddress: storage.AddressRoleSender | storage.AddressRoleEvent,
+		first:         storage.AddressRoleReceiver,
+		second:        storage.AddressRoleReceiver,
+	}, roles)
+}
+
+func TestApp_IndexAddressesReplay(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, _ := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	app.InitChain(types.RequestInitChain{})
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	deployTx, _ := tr.getDeployTx(0).Encode()
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: deployTx}))
+	app.EndBlock(types.RequestEndBlock{Height: 1})
+	app.Commit()
+	assert.Equal(t, uint64(1), app.Meta.AddressTransactionCount(senderAddress))
+
+	// Block replayed by Tendermint after restart is indexed once
+	app.indexAddresses(app.Chain.CurrentBlock)
+	assert.Equal(t, uint64(1), app.Meta.AddressTransactionCount(senderAddress))
+	txHash, roles, err := app.Meta.AddressTransaction(senderAddress, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, app.Chain.CurrentBlock.Transactions()[0].Hash(), txHash)
+	assert.Equal(t, storage.AddressRoleSender, roles)
+	assert.Equal(t, uint64(1), app.Meta.AddressRoleTransactionCount(senderAddress, storage.AddressRoleSender))
+	assert.Equal(t, uint64(0), app.Meta.AddressRoleTransactionCount(senderAddress, storage.AddressRoleReceiver))
+	roleTxHash, roleRoles, err := app.Meta.AddressRoleTransaction(senderAddress, storage.AddressRoleSender, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, txHash, roleTxHash)
+	assert.Equal(t, roles, roleRoles)
+}
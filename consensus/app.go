@@ -33,6 +33,14 @@ type App struct {
 
 	gasStation         gas.Station
 	gasContractAddress string
+	chainID            string
+	genesis            *Genesis
+	distribution       *gas.Distribution
+	feeRates           map[crypto.Address]uint64
+	blockEvents        []*crypto.Event
+	executedTxs        uint32
+	signatures         *signatureCache
+	blockTxs           func(height int64) [][]byte
 }
 
 // We use this code to communicate with Tendermint
@@ -61,24 +69,73 @@ func NewApp(dbDir string, gasContractAddress string) *App {
 	if _, err := os.Stat(dbDir); os.IsNotExist(err) {
 		os.Mkdir(dbDir, os.ModePerm)
 	}
+	return newApp(
+		storage.NewMetaStorage(db.NewRocksDB(filepath.Join(dbDir, metaDBDir))),
+		storage.NewStateStorage(db.NewRocksDB(filepath.Join(dbDir, stateDBDir))),
+		storage.NewChainStorage(db.NewRocksDB(filepath.Join(dbDir, chainDBDir))),
+		gasContractAddress,
+	)
+}
+
+// newApp initializes app on storages, gas station active in latest block is restored
+// so a restarted node does not switch station again
+func newApp(meta *storage.MetaStorage, state *storage.StateStorage, chain *storage.ChainStorage, gasContractAddress string) *App {
 	app := &App{
-		Meta:               storage.NewMetaStorage(db.NewRocksDB(filepath.Join(dbDir, metaDBDir))),
-		State:              storage.NewStateStorage(db.NewRocksDB(filepath.Join(dbDir, stateDBDir))),
-		Chain:              storage.NewChainStorage(db.NewRocksDB(filepath.Join(dbDir, chainDBDir))),
+		Meta:               meta,
+		State:              state,
+		Chain:              chain,
 		gasContractAddress: gasContractAddress,
+		feeRates:           make(map[crypto.Address]uint64),
+		signatures:         newSignatureCache(),
+	}
+	genesis, err := ParseGenesis(app.Meta.Genesis())
+	if err != nil {
+		panic(err)
 	}
-	app.SetGasStation(gas.NewFreeStation(app))
+	app.genesis = genesis
+	app.chainID = app.Meta.ChainID()
+	app.State.SetStorageSizeTracking(genesis.StorageRent.Enabled())
+	app.State.SetScheduleGasLimit(genesis.ScheduleGasLimit)
+	station, _ := app.Meta.GasStation(app.Meta.LatestBlockHeight())
+	app.SetGasStation(gas.NewStation(gas.StationID(station), app))
 	return app
 }
 
+// InitChain loads application settings from genesis
+func (app *App) InitChain(req abciTypes.RequestInitChain) abciTypes.ResponseInitChain {
+	genesis, err := ParseGenesis(req.AppStateBytes)
+	if err != nil {
+		panic(err)
+	}
+	app.Meta.StoreGenesis(req.AppStateBytes)
+	app.Meta.StoreChainID(req.ChainId)
+	app.genesis = genesis
+	app.chainID = req.ChainId
+	app.State.SetStorageSizeTracking(genesis.StorageRent.Enabled())
+	app.State.SetScheduleGasLimit(genesis.ScheduleGasLimit)
+	return abciTypes.ResponseInitChain{}
+}
+
 // BeginBlock begins new block
 func (app *App) BeginBlock(req abciTypes.RequestBeginBlock) abciTypes.ResponseBeginBlock {
 	lastBlockHash := appHashToBlockHash(req.Header.AppHash)
 	previousBlock := app.Chain.MustGetBlock(lastBlockHash)
 	app.State.MustLoadState(previousBlock)
 	app.Chain.ComposeBlock(previousBlock, req.Header.Time)
-	for app.gasStation.Switch() {
-	}
+	app.State.SetExecutingBlock(app.Chain.CurrentBlock)
+	app.preverifyBlock(req.Header.Height)
+	app.feeRates = make(map[crypto.Address]uint64)
+	app.Chain.CurrentBlock.SetBaseFee(app.genesis.BaseFee.Next(previousBlock.BaseFee, previousBlock.GasUsed))
+	app.blockEvents = nil
+	app.executedTxs = 0
+	app.creditGenesisBalances()
+	app.switchGasStation()
+	_, schedule := app.genesis.GasScheduleAt(app.Chain.CurrentBlock.Height)
+	app.gasStation.SetSchedule(schedule)
+	app.gasStation.SetBaseFee(app.Chain.CurrentBlock.BaseFee)
+	app.distribution = app.feeDistribution(req)
+	app.addSystemReceipt(app.blockEvents)
+	app.runScheduledCalls()
 	return abciTypes.ResponseBeginBlock{}
 }
 
@@ -126,10 +183,21 @@ func (app *App) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeli
 		return abciTypes.ResponseDeliverTx{Code: ResponseCodeNotOK}
 	}
 
-	if err := app.validateTx(tx); err != nil {
+	err = app.validateTx(tx)
+	app.signatures.remove(tx.Hash())
+	if err != nil {
 		return abciTypes.ResponseDeliverTx{Code: ResponseCodeNotOK}
 	}
 
+	// Tx beyond block limits is not recorded in block, so it can be included again with the same nonce
+	if !app.fitInBlock(tx) {
+		return abciTypes.ResponseDeliverTx{
+			Code: uint32(crypto.ReceiptCodeBlockLimitExceeded),
+			Log:  "Block limit exceeded",
+		}
+	}
+	app.executedTxs++
+
 	receipt, err := app.applyTransaction(tx)
 	if err != nil {
 		panic(err)
@@ -143,12 +211,20 @@ func (app *App) DeliverTx(req abciTypes.RequestDeliverTx) abciTypes.ResponseDeli
 	return abciTypes.ResponseDeliverTx{Code: ResponseCodeOK}
 }
 
+// EndBlock pays out fees collected in block, its transfers are recorded in a system receipt
+func (app *App) EndBlock(req abciTypes.RequestEndBlock) abciTypes.ResponseEndBlock {
+	app.addSystemReceipt(app.gasStation.Distribute(app.distribution))
+	return abciTypes.ResponseEndBlock{}
+}
+
 // Commit returns the state root of application storage. Called once all block processing is complete
 func (app *App) Commit() abciTypes.ResponseCommit {
 	blockHash := app.Chain.Commit(app.State.Commit())
+	app.indexAddresses(app.Chain.CurrentBlock)
 	if err := app.Meta.StoreBlockMetas(app.Chain.CurrentBlock); err != nil {
 		log.Println("unable to store index for block", blockHash)
 	}
+	app.Meta.StoreGasStation(app.Chain.CurrentBlock.Height, byte(app.gasStation.ID()))
 	return abciTypes.ResponseCommit{Data: blockHashToAppHash(blockHash)}
 }
 
@@ -157,6 +233,11 @@ func (app *App) SetGasStation(gasStation gas.Station) {
 	app.gasStation = gasStation
 }
 
+// GetNativeToken returns token moving native balances
+func (app *App) GetNativeToken() gas.Token {
+	return token.NewNativeToken(app.State)
+}
+
 // GetGasContractToken designated
 func (app *App) GetGasContractToken() gas.Token {
 	if len(app.gasContractAddress) > 0 {
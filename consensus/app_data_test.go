@@ -190,3 +190,27 @@ func (tr TestResource) getInvalidSerializedTx(nonce int) *crypto.Transaction {
 	tx.Signature = crypto.Sign(privateKey, dataToSign.Bytes())
 	return tx
 }
+
+func (tr TestResource) getSponsoredInvokeTx(nonce int, version uint16) (*crypto.Transaction, ed25519.PrivateKey) {
+	tx := tr.getInvokeTx(nonce)
+	_, privateKey := tr.getSenderWithNonce(nonce)
+	payerSeed := make([]byte, 32)
+	payerSeed[0] = 1
+	payerKey := ed25519.NewKeyFromSeed(payerSeed)
+
+	tx.Version = version
+	tx.FeePayer = payerKey.Public().(ed25519.PublicKey)
+	dataToSign := crypto.GetSigHash(tx)
+	tx.Signature = crypto.Sign(privateKey, dataToSign.Bytes())
+	tx.FeePayerSignature = crypto.Sign(payerKey, dataToSign.Bytes())
+	return tx, payerKey
+}
+
+func (tr TestResource) getPayloadTx(nonce int, payload *crypto.TxPayload) *crypto.Transaction {
+	tx := tr.getInvokeTx(nonce)
+	_, privateKey := tr.getSenderWithNonce(nonce)
+	tx.Payload = payload
+	dataToSign := crypto.GetSigHash(tx)
+	tx.Signature = crypto.Sign(privateKey, dataToSign.Bytes())
+	return tx
+}
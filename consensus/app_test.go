@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"fmt"
 	"math/rand"
 	"os"
@@ -12,6 +13,8 @@ import (
 	"github.com/QuoineFinancial/liquid-chain/common"
 	"github.com/QuoineFinancial/liquid-chain/constant"
 	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/tendermint/tendermint/abci/types"
@@ -47,6 +50,33 @@ func TestNewApp(t *testing.T) {
 	assert.NotNil(t, app)
 }
 
+func TestApp_InitChain(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	appState := []byte(`{
+		"gasSchedule": {"minimumGasPrice": 10},
+		"gasUpgrades": [{"name": "storage-v2", "height": 2, "schedule": {"storageByte": 4}}],
+		"baseFee": {"initial": 10, "minimum": 10}
+	}`)
+	got := app.InitChain(types.RequestInitChain{AppStateBytes: appState})
+	if !cmp.Equal(got, types.ResponseInitChain{}) {
+		t.Errorf("App.InitChain() = %v, want %v", got, types.ResponseInitChain{})
+	}
+	assert.Equal(t, appState, app.Meta.Genesis())
+
+	app.SetGasStation(gas.NewLiquidStation(app, crypto.Address{}))
+	appHash := []byte{}
+	for height, storageCost := range []uint64{100, 400} {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: int64(height + 1), AppHash: appHash}})
+		assert.Equal(t, storageCost, app.gasStation.GetPolicy().GetCostForStorage(100))
+		assert.False(t, app.gasStation.CheckGasPrice(9))
+		assert.True(t, app.gasStation.CheckGasPrice(10))
+		appHash = app.Commit().Data
+	}
+}
+
 func TestApp_BeginBlock(t *testing.T) {
 	tr := newAppTestResource()
 	defer tr.cleanData()
@@ -263,6 +293,251 @@ func TestApp_DeliverTx(t *testing.T) {
 	})
 }
 
+func TestApp_BlockLimits(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"blockGasLimit": 100, "blockMaxTransactions": 1}`)})
+
+	t.Run("CheckTx rejects gas limit above block gas limit", func(t *testing.T) {
+		sender, privateKey := tr.getSenderWithNonce(0)
+		tx := tr.getDeployTx(0)
+		tx.Sender = &sender
+		tx.GasLimit = 101
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		rawTx, _ := tx.Encode()
+		got := app.CheckTx(types.RequestCheckTx{Tx: rawTx})
+		assert.Equal(t, types.ResponseCheckTx{Code: ResponseCodeNotOK, Log: "Gas limit exceed block gas limit 100"}, got)
+	})
+
+	t.Run("DeliverTx rejects transactions beyond block limit", func(t *testing.T) {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+		deployTx, _ := tr.getDeployTx(0).Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: deployTx}))
+
+		invokeTx, _ := tr.getInvokeTx(1).Encode()
+		got := app.DeliverTx(types.RequestDeliverTx{Tx: invokeTx})
+		assert.Equal(t, types.ResponseDeliverTx{Code: uint32(crypto.ReceiptCodeBlockLimitExceeded), Log: "Block limit exceeded"}, got)
+		assert.Equal(t, 1, len(app.Chain.CurrentBlock.Receipts()))
+		assert.Equal(t, 1, len(app.Chain.CurrentBlock.Transactions()))
+		appHash := app.Commit().Data
+
+		// Nonce is not consumed so the transaction fits into next block
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: invokeTx}))
+		app.Commit()
+		height, err := app.Meta.TxHashToBlockHeight(tr.getInvokeTx(1).Hash())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), height)
+	})
+}
+
+func TestApp_EndBlock(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"feeDistribution": {"proposerPercent": 10, "rewardAddresses": {
+		"0A": "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53",
+		"0B": "LCR57ROUHIQ2AV4D3E3D7ZBTR6YXMKZQWTI4KSHSWCUCRXBKNJKKBCNY"
+	}}}`)})
+
+	app.BeginBlock(types.RequestBeginBlock{
+		Header: types.Header{Height: 1, AppHash: []byte{}, ProposerAddress: []byte{0x0a}},
+		LastCommitInfo: types.LastCommitInfo{Votes: []types.VoteInfo{
+			{Validator: types.Validator{Address: []byte{0x0a}, Power: 10}, SignedLastBlock: true},
+			{Validator: types.Validator{Address: []byte{0x0b}, Power: 20}, SignedLastBlock: false},
+			{Validator: types.Validator{Address: []byte{0x0c}, Power: 30}, SignedLastBlock: true},
+		}},
+	})
+	proposer, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	assert.Equal(t, &gas.Distribution{
+		Proposer:        &proposer,
+		ProposerPercent: 10,
+		Validators:      []*gas.FeeShare{{Address: proposer, Power: 10}},
+	}, app.distribution)
+
+	// Free station collects nothing so block has no system receipt
+	assert.Equal(t, types.ResponseEndBlock{}, app.EndBlock(types.RequestEndBlock{Height: 1}))
+	assert.Empty(t, app.Chain.CurrentBlock.SystemReceipts())
+	app.Commit()
+	assert.Equal(t, common.EmptyHash, app.Chain.CurrentBlock.SystemReceiptRoot)
+}
+
+func TestApp_FeeDistributionFallback(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, privateKey := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	tokenAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	app.InitChain(types.RequestInitChain{})
+	beginBlock := types.RequestBeginBlock{
+		Header: types.Header{Height: 1, AppHash: []byte{}, ProposerAddress: []byte{0x0a}},
+		LastCommitInfo: types.LastCommitInfo{Votes: []types.VoteInfo{
+			{Validator: types.Validator{Address: []byte{0x0a}, Power: 10}, SignedLastBlock: true},
+		}},
+	}
+
+	// Without reward addresses nor gas contract there is nobody to pay
+	app.BeginBlock(beginBlock)
+	assert.Equal(t, &gas.Distribution{ProposerPercent: DefaultProposerPercent}, app.distribution)
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000"})
+	tx := &crypto.Transaction{Version: 1, Sender: &sender, Payload: deploy, GasPrice: 1}
+	tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+	rawTx, _ := tx.Encode()
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+	app.EndBlock(types.RequestEndBlock{Height: 1})
+	beginBlock.Header.AppHash = app.Commit().Data
+
+	// Creator of gas contract takes fees of validators without reward address
+	app.gasContractAddress = tokenAddress.String()
+	beginBlock.Header.Height = 2
+	app.BeginBlock(beginBlock)
+	assert.Equal(t, &gas.Distribution{ProposerPercent: DefaultProposerPercent, Fallback: &senderAddress}, app.distribution)
+	app.EndBlock(types.RequestEndBlock{Height: 2})
+	app.Commit()
+	app.gasContractAddress = ""
+
+	// Fallback address of genesis takes precedence
+	fallback, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	app.genesis, _ = ParseGenesis([]byte(`{"feeDistribution": {"proposerPercent": 10, "fallbackAddress": "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53"}}`))
+	assert.Equal(t, &gas.Distribution{ProposerPercent: 10, Fallback: &fallback}, app.feeDistribution(beginBlock))
+}
+
+type burnRecordStation struct {
+	gas.Station
+	burners []crypto.Address
+}
+
+func (station *burnRecordStation) Burn(addr crypto.Address, gasUsed uint64, price uint32, feeToken crypto.Address) []*crypto.Event {
+	station.burners = append(station.burners, addr)
+	return nil
+}
+
+func TestApp_SponsoredTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	deployTx, _ := tr.getDeployTx(0).Encode()
+	app.DeliverTx(types.RequestDeliverTx{Tx: deployTx})
+	appHash := app.Commit().Data
+
+	v1Tx, _ := tr.getSponsoredInvokeTx(1, 1)
+	invalidPayerTx, _ := tr.getSponsoredInvokeTx(1, 2)
+	invalidPayerTx.FeePayerSignature = []byte{1, 2, 3}
+	// Malformed fee payer key is rejected before verifying its signature
+	shortPayerTx, _ := tr.getSponsoredInvokeTx(1, 2)
+	shortPayerTx.FeePayer = []byte{1, 2, 3}
+	_, senderKey := tr.getSenderWithNonce(1)
+	shortPayerTx.Signature = crypto.Sign(senderKey, crypto.GetSigHash(shortPayerTx).Bytes())
+	for _, test := range []struct {
+		tx   *crypto.Transaction
+		want types.ResponseCheckTx
+	}{
+		{v1Tx, types.ResponseCheckTx{Code: ResponseCodeNotOK, Log: "Fee payer requires tx version 2"}},
+		{invalidPayerTx, types.ResponseCheckTx{Code: ResponseCodeNotOK, Log: "Invalid fee payer signature"}},
+		{shortPayerTx, types.ResponseCheckTx{Code: ResponseCodeNotOK, Log: "invalid ed25519 key length 3"}},
+	} {
+		rawTx, _ := test.tx.Encode()
+		assert.Equal(t, test.want, app.CheckTx(types.RequestCheckTx{Tx: rawTx}))
+	}
+
+	tx, payerKey := tr.getSponsoredInvokeTx(1, 2)
+	rawTx, _ := tx.Encode()
+	assert.Equal(t, types.ResponseCheckTx{Code: ResponseCodeOK}, app.CheckTx(types.RequestCheckTx{Tx: rawTx}))
+
+	station := &burnRecordStation{Station: gas.NewFreeStation(app)}
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	app.SetGasStation(station)
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+	assert.Equal(t, []crypto.Address{crypto.AddressFromPubKey(payerKey.Public().(ed25519.PublicKey))}, station.burners)
+
+	// Contract still sees sender as caller, minted amount goes to sender
+	receipt := app.Chain.CurrentBlock.Receipts()[0]
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	senderAddress := crypto.AddressFromPubKey(tx.Sender.PublicKey)
+	assert.True(t, bytes.Contains(receipt.Events[0].Args, senderAddress[:]))
+}
+
+func TestApp_ReplayProtection(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{ChainId: "liquid", AppStateBytes: []byte(`{"chainIdRequiredFrom": 3}`)})
+	assert.Equal(t, "liquid", app.Meta.ChainID())
+
+	_, privateKey := tr.getSenderWithNonce(0)
+	signedTx := func(version uint16, chainID string, expiry uint64) *crypto.Transaction {
+		tx := tr.getDeployTx(0)
+		tx.Version = version
+		tx.ChainID = chainID
+		tx.Expiry = expiry
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	assert.NoError(t, app.validateTx(signedTx(1, "", 0)))
+	assert.NoError(t, app.validateTx(signedTx(3, "liquid", 0)))
+	assert.NoError(t, app.validateTx(signedTx(3, "liquid", 1)))
+	assert.EqualError(t, app.validateTx(signedTx(2, "liquid", 0)), "Chain ID and expiry require tx version 3")
+	assert.EqualError(t, app.validateTx(signedTx(3, "other", 0)), "Invalid chain ID. Expected liquid, got other")
+	appHash := app.Commit().Data
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	assert.EqualError(t, app.validateTx(signedTx(3, "liquid", 1)), "Transaction expired at height 1")
+	appHash = app.Commit().Data
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 3, AppHash: appHash}})
+	assert.EqualError(t, app.validateTx(signedTx(1, "", 0)), "tx version 1 not supported from height 3")
+	assert.NoError(t, app.validateTx(signedTx(3, "liquid", 0)))
+
+	// Zero requires chain ID from genesis
+	app.genesis.ChainIDRequiredFrom = 0
+	assert.EqualError(t, app.validateTx(signedTx(1, "", 0)), "tx version 1 not supported from height 0")
+}
+
+func TestApp_Secp256k1Sender(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	privateKey := make([]byte, 32)
+	privateKey[0] = 1
+	sender := &crypto.TxSender{PublicKey: crypto.Secp256k1PublicKey(privateKey), KeyType: crypto.KeyTypeSecp256k1}
+	senderAddress := sender.Address()
+	receiver, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(fmt.Sprintf(`{"balances": {"%s": 100}}`, senderAddress.String()))})
+
+	transferTx := func(version uint16) *crypto.Transaction {
+		tx := &crypto.Transaction{
+			Version:  version,
+			Sender:   sender,
+			Receiver: receiver,
+			Payload:  &crypto.TxPayload{},
+			GasPrice: 1,
+			Value:    10,
+		}
+		tx.Signature, _ = crypto.SignSecp256k1(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	assert.EqualError(t, app.validateTx(transferTx(2)), "Key type requires tx version 3")
+	invalid := transferTx(3)
+	invalid.Signature[0]++
+	assert.EqualError(t, app.validateTx(invalid), "Invalid signature")
+
+	rawTx, _ := transferTx(3).Encode()
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+	assert.Equal(t, crypto.ReceiptCodeOK, app.Chain.CurrentBlock.Receipts()[0].Code)
+	balance, _ := app.State.GetBalance(senderAddress)
+	assert.Equal(t, uint64(90), balance)
+}
+
 func TestBlockHashAndAppHashConversion(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,40 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// creditGenesisBalances credits native balances of genesis at the beginning of first block.
+// State is committed so balances are kept when a tx of the block reverts. Every credit is recorded as a system event.
+func (app *App) creditGenesisBalances() {
+	if app.Chain.CurrentBlock.Height != 1 || len(app.genesis.balances) == 0 {
+		return
+	}
+	addresses := make([]crypto.Address, 0, len(app.genesis.balances))
+	for address := range app.genesis.balances {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+	for _, address := range addresses {
+		balance := app.genesis.balances[address]
+		if err := app.State.AddBalance(address, balance); err != nil {
+			panic(err)
+		}
+		amount := make([]byte, 8)
+		binary.LittleEndian.PutUint64(amount, balance)
+		app.blockEvents = append(app.blockEvents, newSystemEvent(GenesisBalanceCreditedEvent, address[:], amount))
+	}
+	app.State.Commit()
+}
+
+// transferValue moves value of tx from sender to receiver before execution
+func (app *App) transferValue(tx *crypto.Transaction, receiver crypto.Address) error {
+	senderAddress := tx.SenderAddress()
+	return app.State.Transfer(senderAddress, receiver, tx.Value)
+}
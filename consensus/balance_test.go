@@ -0,0 +1,66 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_NativeBalance(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, privateKey := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	contractAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	appState := fmt.Sprintf(`{"balances": {"%s": 1000000000}, "gasStations": [{"height": 1, "station": "native"}]}`, senderAddress.String())
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(appState)})
+
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000"})
+	transfer, _ := util.BuildInvokeTxPayload("../test/testdata/gas-token-abi.json", "transfer", []string{"LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53", "100", "0"})
+	signedTx := func(nonce uint64, receiver crypto.Address, payload *crypto.TxPayload, value uint64) []byte {
+		tx := &crypto.Transaction{
+			Version:  2,
+			Sender:   &crypto.TxSender{PublicKey: sender.PublicKey, Nonce: nonce},
+			Receiver: receiver,
+			Payload:  payload,
+			GasLimit: 1000000,
+			GasPrice: 18,
+			Value:    value,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		rawTx, _ := tx.Encode()
+		return rawTx
+	}
+	balance := func(address crypto.Address) uint64 {
+		balance, err := app.State.GetBalance(address)
+		assert.NoError(t, err)
+		return balance
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	assert.Equal(t, gas.NativeStationID, app.gasStation.ID())
+	assert.Equal(t, uint64(1000000000), balance(senderAddress))
+
+	// Value is sent to deployed contract and fee is charged from native balance
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(0, crypto.EmptyAddress, deploy, 5000)}))
+	receipt := app.Chain.CurrentBlock.Receipts()[0]
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, uint64(5000), balance(contractAddress))
+	fee := uint64(receipt.GasUsed) * 18
+	assert.Equal(t, 1000000000-5000-fee, balance(senderAddress))
+	assert.Equal(t, fee, balance(gas.BurnAddress)+balance(gas.FeeCollectorAddress))
+
+	// Value above balance is rejected
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeNotOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(1, contractAddress, transfer, 1000000000)}))
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(1, contractAddress, transfer, 10)}))
+	assert.Equal(t, uint64(5010), balance(contractAddress))
+	app.EndBlock(types.RequestEndBlock{})
+	app.Commit()
+}
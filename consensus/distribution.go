@@ -0,0 +1,36 @@
+package consensus
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/gas"
+
+	abciTypes "github.com/tendermint/tendermint/abci/types"
+)
+
+// feeDistribution pays block proposer and validators signed last block
+func (app *App) feeDistribution(req abciTypes.RequestBeginBlock) *gas.Distribution {
+	distribution := &gas.Distribution{
+		ProposerPercent: app.genesis.FeeDistribution.ProposerPercent,
+		Fallback:        app.genesis.feeFallback,
+	}
+	if distribution.Fallback == nil {
+		if token := app.GetGasContractToken(); token != nil {
+			creator := token.GetContract().GetCreator()
+			distribution.Fallback = &creator
+		}
+	}
+	if address, ok := app.rewardAddress(req.Header.ProposerAddress); ok {
+		distribution.Proposer = &address
+	}
+	for _, vote := range req.LastCommitInfo.Votes {
+		if !vote.SignedLastBlock || vote.Validator.Power <= 0 {
+			continue
+		}
+		if address, ok := app.rewardAddress(vote.Validator.Address); ok {
+			distribution.Validators = append(distribution.Validators, &gas.FeeShare{
+				Address: address,
+				Power:   uint64(vote.Validator.Power),
+			})
+		}
+	}
+	return distribution
+}
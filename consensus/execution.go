@@ -16,15 +16,34 @@ var (
 )
 
 func (app *App) applyTransaction(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	if tx.IsMultiCall() {
+		return app.invokeCalls(tx)
+	}
 	if tx.Receiver == crypto.EmptyAddress {
 		return app.deployContract(tx)
 	}
+	if tx.IsUpgrade() {
+		return app.upgradeContract(tx)
+	}
+	if tx.IsTransfer() {
+		return app.transfer(tx)
+	}
+	if tx.IsMultisigConfig() {
+		return app.configureMultisig(tx)
+	}
+	if tx.IsRewardConfig() {
+		return app.configureRewardAddress(tx)
+	}
+	if tx.IsScheduled() {
+		return app.scheduleCall(tx)
+	}
 	return app.invokeContract(tx)
 }
 
 func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error) {
 	receipt := crypto.Receipt{
 		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
 	}
 
 	contractSize := len(tx.Payload.Contract)
@@ -41,12 +60,29 @@ func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 	}
 
 	// Create contract account
-	senderAddress := crypto.AddressFromPubKey(tx.Sender.PublicKey)
-	contractAddress := crypto.NewDeploymentAddress(senderAddress, tx.Sender.Nonce)
+	senderAddress := tx.SenderAddress()
+	contractAddress := tx.DeploymentAddress()
+	existing, err := app.State.LoadAccount(contractAddress)
+	if err != nil {
+		return nil, err
+	}
 	contractAccount, err := app.State.CreateAccount(senderAddress, contractAddress, tx.Payload.Contract)
 	if err != nil {
 		return nil, err
 	}
+	if existing != nil {
+		// Balance sent to address before deployment is kept by contract
+		contractAccount.Balance = existing.Balance
+	}
+	if tx.Admin != crypto.EmptyAddress {
+		contractAccount.SetAdmin(tx.Admin)
+	}
+	if err := app.transferValue(tx, contractAddress); err != nil {
+		receipt.Code = crypto.ReceiptCodeInsufficientBalance
+		app.State.Revert()
+		return &receipt, nil
+	}
+	app.startRent(contractAccount)
 
 	if bytes.Equal(tx.Payload.ID[:], initFunctionID[:]) {
 		function, err := contract.Header.GetFunctionByMethodID(tx.Payload.ID)
@@ -56,10 +92,17 @@ func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 		execEngine := engine.NewEngine(app.State, contractAccount, senderAddress, policy, uint64(tx.GasLimit-receipt.GasUsed))
 		result, err := execEngine.Ignite(function.Name, tx.Payload.Args)
 		receipt.GasUsed += uint32(execEngine.GetGasUsed())
+		if err == nil {
+			// Refund only applies when storage changes are kept
+			receipt.GasUsed -= uint32(policy.GetRefund(uint64(receipt.GasUsed), execEngine.GetRefund()))
+			// Destructed contracts are deleted before fee is checked, so destructing token paying the fee fails as out of gas
+			app.deleteDestructed(execEngine.GetDestructed())
+		}
+
 		if err != nil {
 			receipt.Code = crypto.ReceiptCodeIgniteError
 			app.State.Revert()
-		} else if !app.gasStation.Sufficient(senderAddress, uint64(receipt.GasUsed)*uint64(tx.GasPrice)) {
+		} else if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
 			receipt.Code = crypto.ReceiptCodeOutOfGas
 			receipt.GasUsed = tx.GasLimit
 			app.State.Revert()
@@ -67,6 +110,8 @@ func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 			receipt.Result = result
 			receipt.Code = crypto.ReceiptCodeOK
 			receipt.Events = append(receipt.Events, execEngine.GetEvents()...)
+			app.startRent(contractAccount)
+			app.settleRent()
 		}
 	}
 
@@ -75,7 +120,7 @@ func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 		return nil, err
 	}
 
-	gasEvents := app.gasStation.Burn(senderAddress, uint64(receipt.GasUsed)*uint64(tx.GasPrice))
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
 	receipt.Events = append(receipt.Events, gasEvents...)
 	receipt.PostState = app.State.Hash()
 	return &receipt, nil
@@ -84,16 +129,27 @@ func (app *App) deployContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 func (app *App) invokeContract(tx *crypto.Transaction) (*crypto.Receipt, error) {
 	receipt := crypto.Receipt{
 		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
 	}
 
-	contractAccount, err := app.State.LoadAccount(tx.Receiver)
+	// Top up pays rent in arrears itself
+	contractAccount, rentEvents, err := app.loadContract(tx.Receiver, !app.isRentTopUp(tx))
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	receipt.Events = rentEvents
 
 	if contractAccount == nil {
 		receipt.Code = crypto.ReceiptCodeContractNotFound
-		return &receipt, nil
+		return app.chargeUnexecuted(tx, &receipt)
+	}
+
+	if app.isRentTopUp(tx) {
+		return app.topUpRent(tx, contractAccount)
+	}
+	if app.dormant(contractAccount) {
+		receipt.Code = crypto.ReceiptCodeContractDormant
+		return app.chargeUnexecuted(tx, &receipt)
 	}
 
 	contract, err := contractAccount.GetContract()
@@ -103,26 +159,41 @@ func (app *App) invokeContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 	function, err := contract.Header.GetFunctionByMethodID(tx.Payload.ID)
 	if err != nil {
 		receipt.Code = crypto.ReceiptCodeMethodNotFound
-		return &receipt, nil
+		return app.chargeUnexecuted(tx, &receipt)
+	}
+
+	// Value is received before execution, it is reverted with the rest of changes on failure
+	if err := app.transferValue(tx, tx.Receiver); err != nil {
+		receipt.Code = crypto.ReceiptCodeInsufficientBalance
+		return app.chargeUnexecuted(tx, &receipt)
 	}
 
 	policy := app.gasStation.GetPolicy()
-	senderAddress := crypto.AddressFromPubKey(tx.Sender.PublicKey)
+	senderAddress := tx.SenderAddress()
 	execEngine := engine.NewEngine(app.State, contractAccount, senderAddress, policy, uint64(tx.GasLimit))
 
 	result, err := execEngine.Ignite(function.Name, tx.Payload.Args)
 	receipt.GasUsed = uint32(execEngine.GetGasUsed())
+	if err == nil {
+		// Refund only applies when storage changes are kept
+		receipt.GasUsed -= uint32(policy.GetRefund(uint64(receipt.GasUsed), execEngine.GetRefund()))
+		// Destructed contracts are deleted before fee is checked, so destructing token paying the fee fails as out of gas
+		app.deleteDestructed(execEngine.GetDestructed())
+	}
 
 	if err != nil {
 		receipt.Code = crypto.ReceiptCodeIgniteError
+		receipt.Events = nil
 		app.State.Revert()
-	} else if !app.gasStation.Sufficient(senderAddress, uint64(receipt.GasUsed)*uint64(tx.GasPrice)) {
+	} else if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
 		receipt.Code = crypto.ReceiptCodeOutOfGas
 		receipt.GasUsed = tx.GasLimit
+		receipt.Events = nil
 		app.State.Revert()
 	} else {
 		receipt.Result = result
 		receipt.Events = append(receipt.Events, execEngine.GetEvents()...)
+		app.settleRent()
 	}
 
 	// Create/get account for creator and increase nonce by 1
@@ -130,13 +201,43 @@ func (app *App) invokeContract(tx *crypto.Transaction) (*crypto.Receipt, error)
 		return nil, err
 	}
 
-	gasEvents := app.gasStation.Burn(senderAddress, uint64(receipt.GasUsed)*uint64(tx.GasPrice))
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
 	receipt.Events = append(receipt.Events, gasEvents...)
 	receipt.PostState = app.State.Hash()
 
 	return &receipt, nil
 }
 
+// chargeUnexecuted charges call overhead of tx rejected before execution and uses its nonce,
+// so it cannot be included again for free. Changes made before, such as rent charged or
+// pruned contract, are kept.
+func (app *App) chargeUnexecuted(tx *crypto.Transaction, receipt *crypto.Receipt) (*crypto.Receipt, error) {
+	receipt.GasUsed = uint32(app.gasStation.GetPolicy().GetCostForCall())
+	if receipt.GasUsed > tx.GasLimit {
+		receipt.GasUsed = tx.GasLimit
+	}
+	return app.chargeGasUsed(tx, receipt)
+}
+
+// chargeGasUsed uses nonce of tx and burns gas used of receipt from payer
+func (app *App) chargeGasUsed(tx *crypto.Transaction, receipt *crypto.Receipt) (*crypto.Receipt, error) {
+	if err := app.increaseNonce(tx.SenderAddress()); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return receipt, nil
+}
+
+// deleteDestructed removes contracts self destructed by a successful execution
+func (app *App) deleteDestructed(addresses []crypto.Address) {
+	for _, address := range addresses {
+		app.State.DeleteAccount(address)
+	}
+}
+
 func (app *App) increaseNonce(address crypto.Address) error {
 	account, err := app.State.LoadAccount(address)
 	if err != nil {
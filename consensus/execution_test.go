@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/rand"
 	"os"
 	"strconv"
@@ -16,6 +17,8 @@ import (
 	"github.com/QuoineFinancial/liquid-chain/crypto"
 	"github.com/QuoineFinancial/liquid-chain/gas"
 	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
 )
 
 type TestResource struct {
@@ -350,3 +353,72 @@ func TestApplyTx(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteDestructed(t *testing.T) {
+	tr := newTestResource()
+	defer tr.cleanData()
+
+	tx := tr.getDeployTx(0)
+	tx.GasLimit = 10000000
+	if _, err := tr.app.applyTransaction(tx); err != nil {
+		t.Fatal(err)
+	}
+	tr.app.State.Commit()
+
+	senderAddress := crypto.AddressFromPubKey(tx.Sender.PublicKey)
+	contractAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	tr.app.deleteDestructed([]crypto.Address{contractAddress})
+	if account, _ := tr.app.State.LoadAccount(contractAddress); account != nil {
+		t.Errorf("LoadAccount() = %v, want nil", account)
+	}
+	tr.app.State.Commit()
+	if account, _ := tr.app.State.GetAccount(contractAddress); account != nil {
+		t.Errorf("GetAccount() = %v, want nil", account)
+	}
+	if err := tr.app.validateTx(tr.getInvokeTx(1)); err == nil || err.Error() != "Invoke nil contract" {
+		t.Errorf("validateTx() error = %v, want Invoke nil contract", err)
+	}
+}
+
+func TestApp_SaltedDeployment(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, privateKey := tr.getSenderWithNonce(0)
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/liquid-token.wasm", "../test/testdata/liquid-token-abi.json", "", nil)
+	signedTx := func(nonce uint64, version uint16, salt []byte) *crypto.Transaction {
+		tx := &crypto.Transaction{
+			Version:  version,
+			Sender:   &crypto.TxSender{PublicKey: sender.PublicKey, Nonce: nonce},
+			Payload:  deploy,
+			GasPrice: 1,
+			Salt:     salt,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+
+	// Counterfactual address is known and funded before deployment
+	contractAddress := signedTx(0, 3, []byte{1}).DeploymentAddress()
+	assert.Equal(t, contractAddress, signedTx(5, 3, []byte{1}).DeploymentAddress())
+	assert.True(t, contractAddress.IsContract())
+	appState := fmt.Sprintf(`{"balances": {"%s": 100}}`, contractAddress.String())
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(appState)})
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1}})
+
+	assert.EqualError(t, app.validateTx(signedTx(0, 2, []byte{1})), "Salt requires tx version 3")
+	assert.EqualError(t, app.validateTx(signedTx(0, 3, make([]byte, crypto.MaxSaltLength+1))), "Salt exceeds 32 bytes")
+
+	rawTx, _ := signedTx(0, 3, []byte{1}).Encode()
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+	assert.Equal(t, crypto.ReceiptCodeOK, app.Chain.CurrentBlock.Receipts()[0].Code)
+	account, err := app.State.LoadAccount(contractAddress)
+	assert.NoError(t, err)
+	assert.True(t, account.IsContract())
+	assert.Equal(t, uint64(100), account.Balance)
+
+	// Same salt and code of creator cannot deploy twice, other salt can
+	assert.EqualError(t, app.validateTx(signedTx(1, 3, []byte{1})), "Contract already deployed at "+contractAddress.String())
+	assert.NoError(t, app.validateTx(signedTx(1, 3, []byte{2})))
+}
@@ -0,0 +1,71 @@
+package consensus
+
+import (
+	"encoding/binary"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/token"
+)
+
+// feeRate reads rate of a fee token from storage of fee oracle contract.
+// The oracle keeps rates under token address keys as little endian uint64 in gas.RatePrecision,
+// tokens without rate are not accepted.
+func (app *App) feeRate(address crypto.Address) uint64 {
+	if rate, ok := app.feeRates[address]; ok {
+		return rate
+	}
+
+	rate := uint64(0)
+	if app.genesis.feeOracle != crypto.EmptyAddress {
+		oracle, err := app.State.LoadAccount(app.genesis.feeOracle)
+		if err != nil {
+			panic(err)
+		}
+		if oracle != nil {
+			value, err := oracle.GetStorage(address[:])
+			if err != nil {
+				panic(err)
+			}
+			if len(value) == 8 {
+				rate = binary.LittleEndian.Uint64(value)
+			}
+		}
+	}
+
+	// Rates are fixed for the rest of block once read
+	app.feeRates[address] = rate
+	return rate
+}
+
+// GetFeeToken returns token accepted for fee with its rate, nil if token is not whitelisted by oracle
+func (app *App) GetFeeToken(address crypto.Address) (gas.Token, uint64) {
+	rate := app.feeRate(address)
+	if rate == 0 {
+		return nil, 0
+	}
+	contract, err := app.State.LoadAccount(address)
+	if err != nil {
+		panic(err)
+	}
+	if contract == nil || !contract.IsContract() {
+		return nil, 0
+	}
+	return token.NewToken(app.State, contract), rate
+}
+
+// SetFeeTokenCollected records whether collector holds balance of fee token
+func (app *App) SetFeeTokenCollected(collector crypto.Address, token crypto.Address, collected bool) {
+	if err := app.State.SetTokenCollected(collector, token, collected); err != nil {
+		panic(err)
+	}
+}
+
+// CollectedFeeTokens returns fee tokens collector holds balance of, ordered by address
+func (app *App) CollectedFeeTokens(collector crypto.Address) []crypto.Address {
+	tokens, err := app.State.CollectedTokens(collector)
+	if err != nil {
+		panic(err)
+	}
+	return tokens
+}
@@ -0,0 +1,143 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_GetFeeToken(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	oracleAddress := crypto.NewDeploymentAddress(crypto.EmptyAddress, 1000)
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"feeOracle": "` + oracleAddress.String() + `"}`)})
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	deployTx := tr.getDeployTx(0)
+	rawTx, _ := deployTx.Encode()
+	app.DeliverTx(types.RequestDeliverTx{Tx: rawTx})
+	tokenAddress := crypto.NewDeploymentAddress(crypto.AddressFromPubKey(deployTx.Sender.PublicKey), 0)
+
+	oracle, err := app.State.CreateAccount(oracleAddress, oracleAddress, nil)
+	assert.NoError(t, err)
+	setRate := func(address crypto.Address, rate uint64) {
+		value := make([]byte, 8)
+		binary.LittleEndian.PutUint64(value, rate)
+		assert.NoError(t, oracle.SetStorage(address[:], value))
+	}
+	setRate(tokenAddress, 2*gas.RatePrecision)
+	setRate(oracleAddress, gas.RatePrecision)
+
+	token, rate := app.GetFeeToken(tokenAddress)
+	assert.NotNil(t, token)
+	assert.Equal(t, 2*gas.RatePrecision, rate)
+
+	// Rated address which is not a contract is not a token
+	token, _ = app.GetFeeToken(oracleAddress)
+	assert.Nil(t, token)
+
+	token, _ = app.GetFeeToken(crypto.NewDeploymentAddress(crypto.EmptyAddress, 1001))
+	assert.Nil(t, token)
+
+	// Rate stays the same until next block
+	setRate(tokenAddress, 3*gas.RatePrecision)
+	_, rate = app.GetFeeToken(tokenAddress)
+	assert.Equal(t, 2*gas.RatePrecision, rate)
+	appHash := app.Commit().Data
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	_, rate = app.GetFeeToken(tokenAddress)
+	assert.Equal(t, 3*gas.RatePrecision, rate)
+}
+
+func TestApp_CollectedFeeTokens(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	app.InitChain(types.RequestInitChain{})
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	first := crypto.NewDeploymentAddress(crypto.EmptyAddress, 1000)
+	second := crypto.NewDeploymentAddress(crypto.EmptyAddress, 1001)
+	assert.Empty(t, app.CollectedFeeTokens(gas.FeeCollectorAddress))
+	app.SetFeeTokenCollected(gas.FeeCollectorAddress, first, true)
+	app.SetFeeTokenCollected(gas.FeeCollectorAddress, second, true)
+	app.SetFeeTokenCollected(gas.FeeCollectorAddress, second, true)
+	appHash := app.Commit().Data
+
+	// Tokens are kept in state of collector in address order
+	expected := []crypto.Address{first, second}
+	if bytes.Compare(first[:], second[:]) > 0 {
+		expected = []crypto.Address{second, first}
+	}
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	assert.Equal(t, expected, app.CollectedFeeTokens(gas.FeeCollectorAddress))
+	app.SetFeeTokenCollected(gas.FeeCollectorAddress, first, false)
+	assert.Equal(t, []crypto.Address{second}, app.CollectedFeeTokens(gas.FeeCollectorAddress))
+}
+
+func TestApp_SelfDestructFeeToken(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, privateKey := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	tokenAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	oracleAddress := crypto.NewDeploymentAddress(crypto.EmptyAddress, 1000)
+	appState := fmt.Sprintf(`{"balances": {"%s": 1000000000}, "gasStations": [{"height": 1, "station": "native"}], "feeOracle": "%s"}`, senderAddress.String(), oracleAddress.String())
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(appState)})
+
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/destructible-token.wasm", "../test/testdata/destructible-token-abi.json", "", nil)
+	destroy, _ := util.BuildInvokeTxPayload("../test/testdata/destructible-token-abi.json", "destroy", nil)
+	signedTx := func(nonce uint64, receiver crypto.Address, payload *crypto.TxPayload, feeToken crypto.Address) []byte {
+		tx := &crypto.Transaction{
+			Version:  2,
+			Sender:   &crypto.TxSender{PublicKey: sender.PublicKey, Nonce: nonce},
+			Receiver: receiver,
+			Payload:  payload,
+			GasLimit: 1000000,
+			GasPrice: 18,
+			FeeToken: feeToken,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		rawTx, _ := tx.Encode()
+		return rawTx
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(0, crypto.EmptyAddress, deploy, crypto.EmptyAddress)}))
+	oracle, err := app.State.CreateAccount(oracleAddress, oracleAddress, nil)
+	assert.NoError(t, err)
+	rate := make([]byte, 8)
+	binary.LittleEndian.PutUint64(rate, gas.RatePrecision)
+	assert.NoError(t, oracle.SetStorage(tokenAddress[:], rate))
+	app.EndBlock(types.RequestEndBlock{})
+	appHash := app.Commit().Data
+
+	// Destructing token paying the fee fails as out of gas, fee is still charged in the token
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(1, tokenAddress, destroy, tokenAddress)}))
+	receipt := app.Chain.CurrentBlock.Receipts()[0]
+	assert.Equal(t, crypto.ReceiptCodeOutOfGas, receipt.Code)
+	account, err := app.State.LoadAccount(tokenAddress)
+	assert.NoError(t, err)
+	assert.NotNil(t, account)
+
+	// Token paid by native balance may destruct itself
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: signedTx(2, tokenAddress, destroy, crypto.EmptyAddress)}))
+	assert.Equal(t, crypto.ReceiptCodeOK, app.Chain.CurrentBlock.Receipts()[1].Code)
+	account, err = app.State.LoadAccount(tokenAddress)
+	assert.NoError(t, err)
+	assert.Nil(t, account)
+	app.EndBlock(types.RequestEndBlock{})
+	app.Commit()
+}
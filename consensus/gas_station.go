@@ -0,0 +1,52 @@
+package consensus
+
+import (
+	"log"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+)
+
+// StationGovernanceKey is storage key where governance contract keeps id of requested gas station as a single byte
+var StationGovernanceKey = []byte("gas_station")
+
+// requestedGasStation returns station requested by governance contract, falling back to genesis schedule
+func (app *App) requestedGasStation(height uint64) (gas.StationID, bool) {
+	if app.genesis.stationGovernance != crypto.EmptyAddress {
+		governance, err := app.State.LoadAccount(app.genesis.stationGovernance)
+		if err != nil {
+			panic(err)
+		}
+		if governance != nil {
+			value, err := governance.GetStorage(StationGovernanceKey)
+			if err != nil {
+				panic(err)
+			}
+			if len(value) == 1 && gas.StationID(value[0]).Selectable() {
+				return gas.StationID(value[0]), true
+			}
+		}
+	}
+	return app.genesis.GasStationAt(height)
+}
+
+// switchGasStation activates station requested for current block, chains without governance
+// and schedule keep switching to liquid station once gas contract token is minted.
+// Every change is recorded as a system event of the block.
+func (app *App) switchGasStation() {
+	from := app.gasStation.ID()
+	if station, ok := app.requestedGasStation(app.Chain.CurrentBlock.Height); ok {
+		// Liquid station cannot charge before gas contract is deployed, native station charges native balance
+		if station != from && (station != gas.LiquidStationID || app.GetGasContractToken() != nil) {
+			log.Println("Change to", station, "station")
+			app.SetGasStation(gas.NewStation(station, app))
+		}
+	} else {
+		for app.gasStation.Switch() {
+		}
+	}
+
+	if to := app.gasStation.ID(); to != from {
+		app.blockEvents = append(app.blockEvents, newSystemEvent(GasStationChangedEvent, []byte{byte(from)}, []byte{byte(to)}))
+	}
+}
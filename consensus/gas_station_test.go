@@ -0,0 +1,102 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_SwitchGasStation(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	governanceAddress := crypto.NewDeploymentAddress(crypto.EmptyAddress, 1000)
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{
+		"gasStations": [{"height": 3, "station": "liquid"}, {"height": 2, "station": "native"}, {"height": 5, "station": "native"}],
+		"stationGovernance": "` + governanceAddress.String() + `"
+	}`)})
+
+	stationChanged := func(from, to gas.StationID) *crypto.Event {
+		return newSystemEvent(GasStationChangedEvent, []byte{byte(from)}, []byte{byte(to)})
+	}
+	runBlock := func(height uint64, appHash []byte) ([]byte, []*crypto.Receipt) {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: int64(height), AppHash: appHash}})
+		app.EndBlock(types.RequestEndBlock{Height: int64(height)})
+		receipts := app.Chain.CurrentBlock.SystemReceipts()
+		return app.Commit().Data, receipts
+	}
+
+	appHash, receipts := runBlock(1, []byte{})
+	assert.Equal(t, gas.FreeStationID, app.gasStation.ID())
+	assert.Empty(t, receipts)
+
+	appHash, receipts = runBlock(2, appHash)
+	assert.Equal(t, gas.NativeStationID, app.gasStation.ID())
+	assert.Equal(t, []*crypto.Event{stationChanged(gas.FreeStationID, gas.NativeStationID)}, receipts[0].Events)
+	station, ok := app.Meta.GasStation(2)
+	assert.True(t, ok)
+	assert.Equal(t, byte(gas.NativeStationID), station)
+
+	// Liquid station is not activated without gas contract
+	appHash, receipts = runBlock(3, appHash)
+	assert.Equal(t, gas.NativeStationID, app.gasStation.ID())
+	assert.Empty(t, receipts)
+
+	// Governance overrides schedule
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 4, AppHash: appHash}})
+	governance, err := app.State.CreateAccount(governanceAddress, governanceAddress, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, governance.SetStorage(StationGovernanceKey, []byte{byte(gas.FreeStationID)}))
+	app.EndBlock(types.RequestEndBlock{Height: 4})
+	appHash = app.Commit().Data
+
+	appHash, receipts = runBlock(5, appHash)
+	assert.Equal(t, gas.FreeStationID, app.gasStation.ID())
+	assert.Equal(t, []*crypto.Event{stationChanged(gas.NativeStationID, gas.FreeStationID)}, receipts[0].Events)
+
+	// Unknown or test only station falls back to schedule
+	governance, err = app.State.LoadAccount(governanceAddress)
+	assert.NoError(t, err)
+	assert.NoError(t, governance.SetStorage(StationGovernanceKey, []byte{byte(gas.DummyStationID)}))
+	requested, _ := app.requestedGasStation(6)
+	assert.Equal(t, gas.NativeStationID, requested)
+	app.State.Revert()
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 6, AppHash: appHash}})
+	governance, err = app.State.LoadAccount(governanceAddress)
+	assert.NoError(t, err)
+	assert.NoError(t, governance.SetStorage(StationGovernanceKey, []byte{0xff}))
+	app.EndBlock(types.RequestEndBlock{Height: 6})
+	appHash = app.Commit().Data
+
+	_, receipts = runBlock(7, appHash)
+	assert.Equal(t, gas.NativeStationID, app.gasStation.ID())
+	assert.Equal(t, []*crypto.Event{stationChanged(gas.FreeStationID, gas.NativeStationID)}, receipts[0].Events)
+}
+
+func TestApp_GasStationRestart(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"gasStations": [{"height": 2, "station": "native"}]}`)})
+
+	runBlock := func(app *App, height uint64, appHash []byte) []byte {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: int64(height), AppHash: appHash}})
+		app.EndBlock(types.RequestEndBlock{Height: int64(height)})
+		return app.Commit().Data
+	}
+	appHash := runBlock(app, 1, []byte{})
+	appHash = runBlock(app, 2, appHash)
+	assert.Equal(t, gas.NativeStationID, app.gasStation.ID())
+
+	// Restarted node resumes on active station and commits the same block
+	restarted := newApp(app.Meta, app.State, app.Chain, "")
+	assert.Equal(t, gas.NativeStationID, restarted.gasStation.ID())
+	want := runBlock(app, 3, appHash)
+	assert.Equal(t, want, runBlock(restarted, 3, appHash))
+	assert.Empty(t, restarted.Chain.CurrentBlock.SystemReceipts())
+}
@@ -0,0 +1,190 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+)
+
+// Default block limits, zero in genesis disables the corresponding limit
+const (
+	DefaultBlockGasLimit        = uint64(20000000)
+	DefaultBlockMaxTransactions = uint32(10000)
+)
+
+// DefaultProposerPercent is share of collected fees paid to block proposer
+const DefaultProposerPercent = uint64(20)
+
+// DefaultChainIDRequiredFrom ends migration window of txs not bound to chain ID, older versions
+// stay replayable on other chains until then
+const DefaultChainIDRequiredFrom = uint64(1000000)
+
+// FeeDistribution configures payout of collected fees at the end of block.
+// RewardAddresses maps hex Tendermint validator address to address receiving its fees until
+// the validator sets one in state, validators without reward address are left out of distribution. Fees of block where no validator
+// has a reward address go to FallbackAddress, or to creator of gas contract when it is not set.
+type FeeDistribution struct {
+	ProposerPercent uint64            `json:"proposerPercent"`
+	RewardAddresses map[string]string `json:"rewardAddresses"`
+	FallbackAddress string            `json:"fallbackAddress"`
+}
+
+// StorageRent configures rent of contract code and storage, rent is disabled when ByteRate is zero.
+// ByteRate is amount of gas contract token per byte per block, deployed contracts are covered for
+// InitialPeriod blocks, rent in arrears is charged from balance of contract when it is accessed
+// and contracts that cannot pay are dormant, then pruned once GracePeriod blocks pass.
+type StorageRent struct {
+	ByteRate      uint64 `json:"byteRate"`
+	InitialPeriod uint64 `json:"initialPeriod"`
+	GracePeriod   uint64 `json:"gracePeriod"`
+}
+
+// Enabled checks whether contracts pay rent
+func (rent *StorageRent) Enabled() bool {
+	return rent != nil && rent.ByteRate > 0
+}
+
+// StationSwitch activates gas station named Station from Height
+type StationSwitch struct {
+	Height  uint64 `json:"height"`
+	Station string `json:"station"`
+}
+
+type stationSwitch struct {
+	height  uint64
+	station gas.StationID
+}
+
+// Genesis contains application settings read from app_state of genesis file.
+// Transactions bound to chain ID by version 3 are required from ChainIDRequiredFrom height, zero requires them from genesis.
+// Scheduled calls run at the start of block up to ScheduleGasLimit gas, zero disables scheduling by transaction.
+type Genesis struct {
+	GasSchedule          *gas.Schedule          `json:"gasSchedule"`
+	GasUpgrades          []*gas.ScheduleUpgrade `json:"gasUpgrades"`
+	BaseFee              *gas.BaseFeeConfig     `json:"baseFee"`
+	BlockGasLimit        uint64                 `json:"blockGasLimit"`
+	BlockMaxTransactions uint32                 `json:"blockMaxTransactions"`
+	FeeDistribution      *FeeDistribution       `json:"feeDistribution"`
+	FeeOracle            string                 `json:"feeOracle"`
+	GasStations          []*StationSwitch       `json:"gasStations"`
+	StationGovernance    string                 `json:"stationGovernance"`
+	StorageRent          *StorageRent           `json:"storageRent"`
+	Balances             map[string]uint64      `json:"balances"`
+	ChainIDRequiredFrom  uint64                 `json:"chainIdRequiredFrom"`
+	ScheduleGasLimit     uint64                 `json:"scheduleGasLimit"`
+
+	gasSchedules      *gas.Schedules
+	rewardAddresses   map[string]crypto.Address
+	feeFallback       *crypto.Address
+	feeOracle         crypto.Address
+	stationSwitches   []stationSwitch
+	stationGovernance crypto.Address
+	balances          map[crypto.Address]uint64
+}
+
+// ParseGenesis decodes app_state, missing settings take default values
+func ParseGenesis(appState []byte) (*Genesis, error) {
+	genesis := Genesis{
+		GasSchedule:          gas.DefaultSchedule(),
+		BaseFee:              gas.DefaultBaseFeeConfig(),
+		BlockGasLimit:        DefaultBlockGasLimit,
+		BlockMaxTransactions: DefaultBlockMaxTransactions,
+		FeeDistribution:      &FeeDistribution{ProposerPercent: DefaultProposerPercent},
+		ChainIDRequiredFrom:  DefaultChainIDRequiredFrom,
+	}
+	if len(appState) > 0 {
+		if err := json.Unmarshal(appState, &genesis); err != nil {
+			return nil, err
+		}
+	}
+
+	gasSchedules, err := gas.NewSchedules(genesis.GasSchedule, genesis.GasUpgrades)
+	if err != nil {
+		return nil, err
+	}
+	genesis.gasSchedules = gasSchedules
+
+	if genesis.FeeDistribution.ProposerPercent > 100 {
+		return nil, fmt.Errorf("proposer percent %d exceeds 100", genesis.FeeDistribution.ProposerPercent)
+	}
+	genesis.rewardAddresses = make(map[string]crypto.Address)
+	for validator, reward := range genesis.FeeDistribution.RewardAddresses {
+		address, err := crypto.AddressFromString(reward)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reward address of validator %s: %v", validator, err)
+		}
+		genesis.rewardAddresses[strings.ToUpper(validator)] = address
+	}
+	if len(genesis.FeeDistribution.FallbackAddress) > 0 {
+		fallback, err := crypto.AddressFromString(genesis.FeeDistribution.FallbackAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee fallback address: %v", err)
+		}
+		genesis.feeFallback = &fallback
+	}
+
+	if len(genesis.FeeOracle) > 0 {
+		feeOracle, err := crypto.AddressFromString(genesis.FeeOracle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee oracle: %v", err)
+		}
+		genesis.feeOracle = feeOracle
+	}
+
+	for _, gasStation := range genesis.GasStations {
+		station, err := gas.ParseStationID(gasStation.Station)
+		if err != nil {
+			return nil, err
+		}
+		genesis.stationSwitches = append(genesis.stationSwitches, stationSwitch{gasStation.Height, station})
+	}
+	sort.SliceStable(genesis.stationSwitches, func(i, j int) bool {
+		return genesis.stationSwitches[i].height < genesis.stationSwitches[j].height
+	})
+
+	if len(genesis.StationGovernance) > 0 {
+		governance, err := crypto.AddressFromString(genesis.StationGovernance)
+		if err != nil {
+			return nil, fmt.Errorf("invalid station governance: %v", err)
+		}
+		genesis.stationGovernance = governance
+	}
+
+	genesis.balances = make(map[crypto.Address]uint64)
+	for account, balance := range genesis.Balances {
+		address, err := crypto.AddressFromString(account)
+		if err != nil {
+			return nil, fmt.Errorf("invalid balance address %s: %v", account, err)
+		}
+		genesis.balances[address] = balance
+	}
+	return &genesis, nil
+}
+
+// GasScheduleAt returns name and gas schedule in effect at height
+func (genesis *Genesis) GasScheduleAt(height uint64) (string, *gas.Schedule) {
+	return genesis.gasSchedules.At(height)
+}
+
+// RewardAddress returns address receiving fees of a Tendermint validator
+func (genesis *Genesis) RewardAddress(validator []byte) (crypto.Address, bool) {
+	address, ok := genesis.rewardAddresses[strings.ToUpper(hex.EncodeToString(validator))]
+	return address, ok
+}
+
+// GasStationAt returns gas station scheduled at height, false if none is scheduled yet
+func (genesis *Genesis) GasStationAt(height uint64) (gas.StationID, bool) {
+	station, scheduled := gas.FreeStationID, false
+	for _, stationSwitch := range genesis.stationSwitches {
+		if stationSwitch.height > height {
+			break
+		}
+		station, scheduled = stationSwitch.station, true
+	}
+	return station, scheduled
+}
@@ -0,0 +1,113 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGenesis(t *testing.T) {
+	t.Run("Empty app state uses default schedule", func(t *testing.T) {
+		genesis, err := ParseGenesis(nil)
+		assert.NoError(t, err)
+		name, schedule := genesis.GasScheduleAt(100)
+		assert.Equal(t, gas.GenesisScheduleName, name)
+		assert.Equal(t, gas.DefaultSchedule(), schedule)
+	})
+
+	t.Run("Partial schedule keeps default values", func(t *testing.T) {
+		genesis, err := ParseGenesis([]byte(`{"gasSchedule": {"eventByte": 2}}`))
+		assert.NoError(t, err)
+		_, schedule := genesis.GasScheduleAt(0)
+		assert.Equal(t, uint64(2), schedule.EventByte)
+		assert.Equal(t, gas.DefaultSchedule().MinimumGasPrice, schedule.MinimumGasPrice)
+	})
+
+	t.Run("Block limits", func(t *testing.T) {
+		genesis, err := ParseGenesis(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultBlockGasLimit, genesis.BlockGasLimit)
+		assert.Equal(t, DefaultBlockMaxTransactions, genesis.BlockMaxTransactions)
+
+		genesis, err = ParseGenesis([]byte(`{"blockGasLimit": 0}`))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), genesis.BlockGasLimit)
+	})
+
+	t.Run("Chain ID required by default", func(t *testing.T) {
+		genesis, err := ParseGenesis(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, DefaultChainIDRequiredFrom, genesis.ChainIDRequiredFrom)
+
+		genesis, err = ParseGenesis([]byte(`{"chainIdRequiredFrom": 0}`))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0), genesis.ChainIDRequiredFrom)
+	})
+
+	t.Run("Fee distribution", func(t *testing.T) {
+		genesis, err := ParseGenesis([]byte(`{"feeDistribution": {"proposerPercent": 30, "rewardAddresses": {"0a0b": "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53"}}}`))
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(30), genesis.FeeDistribution.ProposerPercent)
+		address, ok := genesis.RewardAddress([]byte{0x0a, 0x0b})
+		assert.True(t, ok)
+		assert.Equal(t, "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53", address.String())
+		_, ok = genesis.RewardAddress([]byte{0x0a})
+		assert.False(t, ok)
+
+		_, err = ParseGenesis([]byte(`{"feeDistribution": {"proposerPercent": 101}}`))
+		assert.Error(t, err)
+		_, err = ParseGenesis([]byte(`{"feeDistribution": {"rewardAddresses": {"0a0b": "invalid"}}}`))
+		assert.Error(t, err)
+		_, err = ParseGenesis([]byte(`{"feeDistribution": {"fallbackAddress": "invalid"}}`))
+		assert.Error(t, err)
+
+		// Default genesis has no reward addresses, app falls back to creator of gas contract
+		genesis, err = ParseGenesis(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, genesis.rewardAddresses)
+		assert.Nil(t, genesis.feeFallback)
+	})
+
+	t.Run("Gas stations", func(t *testing.T) {
+		genesis, err := ParseGenesis([]byte(`{"gasStations": [{"height": 20, "station": "free"}, {"height": 10, "station": "liquid"}]}`))
+		assert.NoError(t, err)
+		for _, test := range []struct {
+			height    uint64
+			station   gas.StationID
+			scheduled bool
+		}{
+			{9, gas.FreeStationID, false},
+			{10, gas.LiquidStationID, true},
+			{19, gas.LiquidStationID, true},
+			{20, gas.FreeStationID, true},
+		} {
+			station, scheduled := genesis.GasStationAt(test.height)
+			assert.Equal(t, test.station, station)
+			assert.Equal(t, test.scheduled, scheduled)
+		}
+
+		_, err = ParseGenesis([]byte(`{"gasStations": [{"height": 10, "station": "unknown"}]}`))
+		assert.Error(t, err)
+		_, err = ParseGenesis([]byte(`{"gasStations": [{"height": 10, "station": "dummy"}]}`))
+		assert.EqualError(t, err, "unknown gas station dummy")
+		_, err = ParseGenesis([]byte(`{"stationGovernance": "invalid"}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Balances", func(t *testing.T) {
+		genesis, err := ParseGenesis([]byte(`{"balances": {"LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53": 100}}`))
+		assert.NoError(t, err)
+		address, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+		assert.Equal(t, map[crypto.Address]uint64{address: 100}, genesis.balances)
+
+		_, err = ParseGenesis([]byte(`{"balances": {"invalid": 100}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid upgrades", func(t *testing.T) {
+		_, err := ParseGenesis([]byte(`{"gasUpgrades": [{"name": "v1", "height": 0}]}`))
+		assert.Error(t, err)
+	})
+}
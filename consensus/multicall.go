@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/engine"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+)
+
+// validateMultiCall checks multi-call tx is sent without receiver and every call invokes an existing contract method
+func (app *App) validateMultiCall(tx *crypto.Transaction) error {
+	if tx.Version < 3 {
+		return fmt.Errorf("Multi-call requires tx version 3")
+	}
+	payload := tx.Payload
+	if tx.Receiver != crypto.EmptyAddress || payload.ID != (crypto.MethodID{}) || len(payload.Args) > 0 || len(payload.Contract) > 0 {
+		return fmt.Errorf("Multi-call payload only holds calls and is sent without receiver")
+	}
+	if tx.Value > 0 || tx.Admin != crypto.EmptyAddress || len(tx.Salt) > 0 {
+		return fmt.Errorf("Multi-call does not accept value, admin or salt")
+	}
+	if len(payload.Calls) > crypto.MaxPayloadCalls {
+		return fmt.Errorf("Multi-call exceeds %d calls", crypto.MaxPayloadCalls)
+	}
+	for i, call := range payload.Calls {
+		account, err := app.State.LoadAccount(call.Receiver)
+		if err != nil {
+			return err
+		}
+		if account == nil {
+			return fmt.Errorf("Call %d invokes nil contract", i)
+		}
+		if !account.IsContract() {
+			return fmt.Errorf("Call %d invokes a non-contract account", i)
+		}
+		contract, err := account.GetContract()
+		if err != nil {
+			return fmt.Errorf("Contract is missing, database might be corrupted")
+		}
+		function, err := contract.Header.GetFunctionByMethodID(call.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := abi.DecodeToBytes(function.Parameters, call.Args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invokeCalls executes calls of multi-call tx in order under its gas limit,
+// changes of every call are reverted and their events dropped once any call fails
+func (app *App) invokeCalls(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+	}
+
+	// Calls share gas limit by gross gas used, refund is capped once for the whole tx
+	policy := app.gasStation.GetPolicy()
+	senderAddress := tx.SenderAddress()
+	refund := uint64(0)
+	for _, call := range tx.Payload.Calls {
+		result, callRefund, err := app.invokeCall(call, senderAddress, policy, uint64(tx.GasLimit-receipt.GasUsed))
+		if err != nil {
+			return nil, err
+		}
+		receipt.Calls = append(receipt.Calls, result)
+		receipt.GasUsed += result.GasUsed
+		refund += callRefund
+		if result.Code != crypto.ReceiptCodeOK {
+			receipt.Code = result.Code
+			break
+		}
+	}
+	// Refund only applies when storage changes are kept
+	if receipt.Code == crypto.ReceiptCodeOK {
+		receipt.GasUsed -= uint32(policy.GetRefund(uint64(receipt.GasUsed), refund))
+	}
+
+	if receipt.Code == crypto.ReceiptCodeOK && !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+	}
+	if receipt.Code != crypto.ReceiptCodeOK {
+		app.State.Revert()
+		for _, result := range receipt.Calls {
+			result.Events = nil
+		}
+	} else {
+		app.settleRent()
+	}
+
+	// Create/get account for creator and increase nonce by 1
+	if err := app.increaseNonce(senderAddress); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
+
+// invokeCall executes a call of multi-call tx with remaining gas, contracts it destructs are deleted
+// right away so following calls do not reach them. Gas used of result is gross, uncapped refund is returned aside.
+func (app *App) invokeCall(call *crypto.TxCall, caller crypto.Address, policy gas.Policy, gasLimit uint64) (*crypto.CallResult, uint64, error) {
+	result := crypto.CallResult{}
+	contractAccount, rentEvents, err := app.loadContract(call.Receiver, true)
+	if err != nil {
+		return nil, 0, err
+	}
+	result.Events = rentEvents
+	if contractAccount == nil {
+		result.Code = crypto.ReceiptCodeContractNotFound
+		return &result, 0, nil
+	}
+	if app.dormant(contractAccount) {
+		result.Code = crypto.ReceiptCodeContractDormant
+		return &result, 0, nil
+	}
+
+	contract, err := contractAccount.GetContract()
+	if err != nil {
+		return nil, 0, err
+	}
+	function, err := contract.Header.GetFunctionByMethodID(call.ID)
+	if err != nil {
+		result.Code = crypto.ReceiptCodeMethodNotFound
+		return &result, 0, nil
+	}
+
+	execEngine := engine.NewEngine(app.State, contractAccount, caller, policy, gasLimit)
+	value, err := execEngine.Ignite(function.Name, call.Args)
+	result.GasUsed = uint32(execEngine.GetGasUsed())
+	if err != nil {
+		result.Code = crypto.ReceiptCodeIgniteError
+		return &result, 0, nil
+	}
+	result.Result = value
+	result.Events = append(result.Events, execEngine.GetEvents()...)
+	app.deleteDestructed(execEngine.GetDestructed())
+	return &result, execEngine.GetRefund(), nil
+}
@@ -0,0 +1,189 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_MultiCall(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+
+	deployTx := tr.getDeployTx(0)
+	rawTx, _ := deployTx.Encode()
+	assert.Equal(t, ResponseCodeOK, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}).Code)
+	contractAddress := deployTx.DeploymentAddress()
+	senderAddress := deployTx.SenderAddress()
+
+	mint := func(amount string) *crypto.TxCall {
+		payload, err := util.BuildInvokeTxPayload("../test/testdata/liquid-token-abi.json", "mint", []string{amount})
+		if err != nil {
+			panic(err)
+		}
+		return &crypto.TxCall{Receiver: contractAddress, ID: payload.ID, Args: payload.Args}
+	}
+	multiCallTx := func(nonce int, calls ...*crypto.TxCall) *crypto.Transaction {
+		sender, privateKey := tr.getSenderWithNonce(nonce)
+		tx := &crypto.Transaction{
+			Version:  3,
+			Sender:   &sender,
+			Payload:  &crypto.TxPayload{Calls: calls},
+			GasPrice: 1,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+	balance := func() uint64 {
+		account, _ := app.State.LoadAccount(contractAddress)
+		value, _ := account.GetStorage(senderAddress[:])
+		if len(value) == 0 {
+			return 0
+		}
+		return binary.LittleEndian.Uint64(value)
+	}
+
+	// Payload with calls keeps its encoding through transaction
+	tx := multiCallTx(1, mint("10"), mint("20"))
+	rawTx, _ = tx.Encode()
+	decoded, err := crypto.DecodeTransaction(rawTx)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Payload.Calls, decoded.Payload.Calls)
+	assert.True(t, decoded.IsMultiCall())
+	assert.False(t, decoded.IsTransfer())
+
+	assert.Equal(t, ResponseCodeOK, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}).Code)
+	receipts := app.Chain.CurrentBlock.Receipts()
+	receipt := receipts[len(receipts)-1]
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Len(t, receipt.Calls, 2)
+	for _, result := range receipt.Calls {
+		assert.Equal(t, crypto.ReceiptCodeOK, result.Code)
+		assert.Len(t, result.Events, 1)
+	}
+	assert.Equal(t, uint64(30), balance())
+
+	// Failing call reverts calls before it, nonce is still used
+	missing := mint("1")
+	missing.Receiver = crypto.NewDeploymentAddress(senderAddress, 100)
+	receipt, err = app.applyTransaction(multiCallTx(2, mint("5"), missing))
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, receipt.Code)
+	assert.Len(t, receipt.Calls, 2)
+	assert.Nil(t, receipt.Calls[0].Events)
+	assert.Equal(t, uint64(30), balance())
+	account, _ := app.State.LoadAccount(senderAddress)
+	assert.Equal(t, uint64(3), account.Nonce)
+
+	encoded, _ := receipt.Encode()
+	decodedReceipt, err := crypto.DecodeReceipt(encoded)
+	assert.NoError(t, err)
+	assert.Len(t, decodedReceipt.Calls, 2)
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, decodedReceipt.Calls[1].Code)
+
+	// Call to account without contract is not found like invocation
+	notContract := mint("1")
+	notContract.Receiver = senderAddress
+	receipt, err = app.applyTransaction(multiCallTx(3, notContract))
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, receipt.Code)
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, receipt.Calls[0].Code)
+}
+
+// refundPolicy refunds every storage write as much as it costs
+type refundPolicy struct {
+	*gas.AlphaPolicy
+}
+
+func (p *refundPolicy) GetCostForStorageWrite(oldSize int, newSize int) (uint64, uint64) {
+	cost, _ := p.AlphaPolicy.GetCostForStorageWrite(oldSize, newSize)
+	return cost, cost
+}
+
+type policyStation struct {
+	gas.Station
+	policy gas.Policy
+}
+
+func (station *policyStation) GetPolicy() gas.Policy {
+	return station.policy
+}
+
+func TestApp_MultiCallRefund(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	deployTx := tr.getDeployTx(0)
+	rawTx, _ := deployTx.Encode()
+	assert.Equal(t, ResponseCodeOK, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}).Code)
+	policy := &refundPolicy{gas.NewAlphaPolicy(gas.DefaultSchedule())}
+	app.SetGasStation(&policyStation{Station: gas.NewFreeStation(app), policy: policy})
+	payload, _ := util.BuildInvokeTxPayload("../test/testdata/liquid-token-abi.json", "mint", []string{"10"})
+	mint := &crypto.TxCall{Receiver: deployTx.DeploymentAddress(), ID: payload.ID, Args: payload.Args}
+	apply := func(nonce int, gasLimit uint32) *crypto.Receipt {
+		sender, _ := tr.getSenderWithNonce(nonce)
+		tx := &crypto.Transaction{
+			Version:  3,
+			Sender:   &sender,
+			Payload:  &crypto.TxPayload{Calls: []*crypto.TxCall{mint, mint}},
+			GasPrice: 1,
+			GasLimit: gasLimit,
+		}
+		receipt, err := app.applyTransaction(tx)
+		assert.NoError(t, err)
+		app.State.Commit()
+		return receipt
+	}
+
+	// Balance slot is created first, later calls overwrite it with the same gas
+	apply(1, 1000000)
+	receipt := apply(2, 1000000)
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	gross := receipt.Calls[0].GasUsed + receipt.Calls[1].GasUsed
+	net := receipt.GasUsed
+	assert.Less(t, net, gross)
+
+	// Refund of first call does not raise gas left for the second one
+	receipt = apply(3, gross-1)
+	assert.Equal(t, crypto.ReceiptCodeIgniteError, receipt.Code)
+	receipt = apply(4, gross)
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, net, receipt.GasUsed)
+}
+
+func TestApp_ValidateMultiCallTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	tx := tr.getInvokeTx(0)
+	call := &crypto.TxCall{Receiver: tx.Receiver, ID: tx.Payload.ID, Args: tx.Payload.Args}
+	tx.Payload.Calls = []*crypto.TxCall{call}
+	assert.EqualError(t, app.validateTx(tx), "Multi-call requires tx version 3")
+	tx.Version = 3
+	assert.EqualError(t, app.validateTx(tx), "Multi-call payload only holds calls and is sent without receiver")
+
+	tx = &crypto.Transaction{
+		Version: 3,
+		Sender:  &crypto.TxSender{PublicKey: privateKey.Public().(ed25519.PublicKey)},
+		Payload: &crypto.TxPayload{Calls: []*crypto.TxCall{call}},
+		Salt:    []byte{1},
+	}
+	assert.EqualError(t, app.validateTx(tx), "Multi-call does not accept value, admin or salt")
+	tx.Salt = nil
+	assert.EqualError(t, app.validateTx(tx), "Call 0 invokes nil contract")
+	for len(tx.Payload.Calls) <= crypto.MaxPayloadCalls {
+		tx.Payload.Calls = append(tx.Payload.Calls, call)
+	}
+	assert.EqualError(t, app.validateTx(tx), "Multi-call exceeds 16 calls")
+}
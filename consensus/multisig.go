@@ -0,0 +1,89 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+// validateMultisigFields checks multisig tx is sent by a multisig address and signed by its keys only,
+// configuration is an otherwise empty tx sent to the multisig account itself
+func validateMultisigFields(tx *crypto.Transaction) error {
+	if tx.Version < 3 {
+		return fmt.Errorf("Multisig requires tx version 3")
+	}
+	if !tx.Multisig.IsMultisig() {
+		return fmt.Errorf("Invalid multisig address")
+	}
+	if len(tx.Sender.PublicKey) > 0 || len(tx.Signature) > 0 {
+		return fmt.Errorf("Multisig tx is only signed by multisig keys")
+	}
+	if tx.IsMultisigConfig() {
+		payload := tx.Payload
+		if tx.Receiver != tx.Multisig || tx.Value > 0 || payload.ID != (crypto.MethodID{}) || len(payload.Args) > 0 || len(payload.Contract) > 0 {
+			return fmt.Errorf("Multisig configuration is an empty tx sent to multisig account")
+		}
+		if err := crypto.ValidateMultisigPolicy(tx.MultisigKeys, tx.MultisigThreshold); err != nil {
+			return err
+		}
+	} else if tx.MultisigThreshold > 0 {
+		return fmt.Errorf("Multisig threshold requires multisig keys")
+	}
+	return nil
+}
+
+// multisigPolicy returns keys and threshold verifying tx of multisig account. Account configured before
+// keeps its stored policy until configuration tx is applied, the first configuration must match address.
+func multisigPolicy(tx *crypto.Transaction, account *storage.Account) ([]ed25519.PublicKey, uint64, error) {
+	if account != nil && account.MultisigThreshold > 0 {
+		return account.MultisigKeys, account.MultisigThreshold, nil
+	}
+	if !tx.IsMultisigConfig() {
+		return nil, 0, fmt.Errorf("Multisig account is not configured")
+	}
+	if crypto.NewMultisigAddress(tx.MultisigKeys, tx.MultisigThreshold) != tx.Multisig {
+		return nil, 0, fmt.Errorf("Multisig keys do not match multisig address")
+	}
+	return tx.MultisigKeys, tx.MultisigThreshold, nil
+}
+
+// configureMultisig stores keys and threshold of multisig account, gas is charged for storing keys
+func (app *App) configureMultisig(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+		GasUsed:     uint32(app.gasStation.GetPolicy().GetCostForStorage(len(tx.MultisigKeys) * ed25519.PublicKeySize)),
+	}
+	if tx.GasLimit < receipt.GasUsed {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		return app.chargeGasUsed(tx, &receipt)
+	}
+
+	account, err := app.State.LoadAccount(tx.Multisig)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		if account, err = app.State.CreateAccount(tx.Multisig, tx.Multisig, nil); err != nil {
+			return nil, err
+		}
+	}
+	account.SetMultisig(tx.MultisigKeys, tx.MultisigThreshold)
+	if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		app.State.Revert()
+	}
+
+	if err := app.increaseNonce(tx.Multisig); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
@@ -0,0 +1,142 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_Multisig(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	var privateKeys []ed25519.PrivateKey
+	for i := byte(1); i <= 4; i++ {
+		seed := make([]byte, 32)
+		seed[0] = i
+		privateKeys = append(privateKeys, ed25519.NewKeyFromSeed(seed))
+	}
+	// Policy keys are sorted, signers are found by position
+	keyOf := func(privateKey ed25519.PrivateKey) ed25519.PublicKey {
+		return privateKey.Public().(ed25519.PublicKey)
+	}
+	keys := crypto.SortKeys([]ed25519.PublicKey{keyOf(privateKeys[0]), keyOf(privateKeys[1]), keyOf(privateKeys[2])})
+	multisig := crypto.NewMultisigAddress(keys, 2)
+	assert.True(t, multisig.IsMultisig())
+	assert.Equal(t, multisig, crypto.NewMultisigAddress([]ed25519.PublicKey{keys[2], keys[0], keys[1]}, 2))
+	receiver, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(fmt.Sprintf(`{"balances": {"%s": 1000}}`, multisig.String()))})
+
+	signedTx := func(tx *crypto.Transaction, policy []ed25519.PublicKey, signers ...ed25519.PrivateKey) *crypto.Transaction {
+		tx.Signatures = make([][]byte, len(policy))
+		for _, signer := range signers {
+			for i, key := range policy {
+				if key.Equal(keyOf(signer)) {
+					tx.Signatures[i] = crypto.Sign(signer, crypto.GetSigHash(tx).Bytes())
+				}
+			}
+		}
+		return tx
+	}
+	transferTx := func(nonce uint64, value uint64) *crypto.Transaction {
+		return &crypto.Transaction{
+			Version:  3,
+			Sender:   &crypto.TxSender{Nonce: nonce},
+			Receiver: receiver,
+			Payload:  &crypto.TxPayload{},
+			GasPrice: 1,
+			Value:    value,
+			Multisig: multisig,
+		}
+	}
+	configTx := func(nonce uint64, keys []ed25519.PublicKey, threshold uint64) *crypto.Transaction {
+		return &crypto.Transaction{
+			Version:           3,
+			Sender:            &crypto.TxSender{Nonce: nonce},
+			Receiver:          multisig,
+			Payload:           &crypto.TxPayload{},
+			GasPrice:          1,
+			Multisig:          multisig,
+			MultisigKeys:      keys,
+			MultisigThreshold: threshold,
+		}
+	}
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	assert.EqualError(t, app.validateTx(signedTx(transferTx(0, 10), keys, privateKeys[0], privateKeys[1])), "Multisig account is not configured")
+	assert.EqualError(t, app.validateTx(signedTx(configTx(0, keys, 3), keys, privateKeys...)), "Multisig keys do not match multisig address")
+	assert.EqualError(t, app.validateTx(signedTx(configTx(0, keys, 2), keys, privateKeys[0])), "Invalid multisig signatures")
+
+	// First configuration is signed by keys matching address
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(configTx(0, keys, 2), keys, privateKeys[0], privateKeys[1])).Code)
+	account, err := app.State.LoadAccount(multisig)
+	assert.NoError(t, err)
+	assert.Equal(t, keys, account.MultisigKeys)
+	assert.Equal(t, uint64(2), account.MultisigThreshold)
+	assert.Equal(t, uint64(1000), account.Balance)
+
+	assert.EqualError(t, app.validateTx(signedTx(transferTx(1, 10), keys, privateKeys[2])), "Invalid multisig signatures")
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(transferTx(1, 10), keys, privateKeys[1], privateKeys[2])).Code)
+	balance, _ := app.State.GetBalance(receiver)
+	assert.Equal(t, uint64(10), balance)
+
+	// Rotation is signed by current keys, new keys sign afterwards
+	rotated := crypto.SortKeys([]ed25519.PublicKey{keyOf(privateKeys[0]), keyOf(privateKeys[3])})
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(configTx(2, rotated, 1), keys, privateKeys[0], privateKeys[2])).Code)
+	assert.EqualError(t, app.validateTx(signedTx(transferTx(3, 10), keys, privateKeys[1], privateKeys[2])), "Invalid multisig signatures")
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(transferTx(3, 10), rotated, privateKeys[3])).Code)
+	balance, _ = app.State.GetBalance(receiver)
+	assert.Equal(t, uint64(20), balance)
+
+	// Configuration out of gas keeps keys and uses its nonce
+	app.SetGasStation(&policyStation{Station: app.gasStation, policy: gas.NewAlphaPolicy(gas.DefaultSchedule())})
+	assert.Equal(t, crypto.ReceiptCodeOutOfGas, deliver(signedTx(configTx(4, keys, 2), rotated, privateKeys[3])).Code)
+	account, err = app.State.LoadAccount(multisig)
+	assert.NoError(t, err)
+	assert.Equal(t, rotated, account.MultisigKeys)
+	assert.EqualError(t, app.validateTx(signedTx(configTx(4, keys, 2), rotated, privateKeys[3])), "Invalid nonce. Expected 5, got 4")
+}
+
+func TestApp_ValidateMultisigTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	key := ed25519.NewKeyFromSeed(make([]byte, 32)).Public().(ed25519.PublicKey)
+	multisig := crypto.NewMultisigAddress([]ed25519.PublicKey{key}, 1)
+	tx := tr.getInvokeTx(0)
+	tx.Multisig = multisig
+	assert.EqualError(t, app.validateTx(tx), "Multisig requires tx version 3")
+	tx.Version = 3
+	assert.EqualError(t, app.validateTx(tx), "Multisig tx is only signed by multisig keys")
+	tx.Multisig = crypto.AddressFromPubKey(key)
+	assert.EqualError(t, app.validateTx(tx), "Invalid multisig address")
+
+	tx = &crypto.Transaction{
+		Version:           3,
+		Sender:            &crypto.TxSender{},
+		Receiver:          multisig,
+		Payload:           &crypto.TxPayload{},
+		Multisig:          multisig,
+		MultisigKeys:      []ed25519.PublicKey{key, key},
+		MultisigThreshold: 1,
+	}
+	assert.EqualError(t, app.validateTx(tx), "multisig keys must be sorted and unique")
+	tx.MultisigKeys = []ed25519.PublicKey{key}
+	tx.MultisigThreshold = 2
+	assert.EqualError(t, app.validateTx(tx), "multisig threshold must be between 1 and 1")
+	tx.Receiver = crypto.AddressFromPubKey(key)
+	assert.EqualError(t, app.validateTx(tx), "Multisig configuration is an empty tx sent to multisig account")
+}
@@ -0,0 +1,195 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+// RentTopUpFunctionName is reserved method extending rent of invoked contract when rent is enabled,
+// anyone may call it with an amount of gas contract token
+const RentTopUpFunctionName = "topup_rent"
+
+var (
+	rentTopUpFunctionID = crypto.GetMethodID(RentTopUpFunctionName)
+	rentTopUpParameters = []*abi.Parameter{{Name: "amount", Type: abi.Uint64}}
+)
+
+const rentTopUpMemo = uint64(0)
+
+// rentEnabled checks whether contracts pay rent
+func (app *App) rentEnabled() bool {
+	return app.genesis.StorageRent.Enabled()
+}
+
+// isRentTopUp checks whether tx invokes reserved rent top up method
+func (app *App) isRentTopUp(tx *crypto.Transaction) bool {
+	return app.rentEnabled() && tx.Receiver != crypto.EmptyAddress && tx.Payload.ID == rentTopUpFunctionID
+}
+
+// prunable checks whether rent of account is behind for longer than grace period
+func (app *App) prunable(account *storage.Account) bool {
+	if !app.rentEnabled() || account.PaidUntil == 0 {
+		return false
+	}
+	height := app.Chain.CurrentBlock.Height
+	return height > account.PaidUntil && height-account.PaidUntil > app.genesis.StorageRent.GracePeriod
+}
+
+// loadContract loads invoked contract charging its rent in arrears unless skipped,
+// contract pruned for being behind rent past grace period or missing is returned nil
+func (app *App) loadContract(address crypto.Address, chargeRent bool) (*storage.Account, []*crypto.Event, error) {
+	contractAccount, err := app.State.LoadAccount(address)
+	if err != nil {
+		return nil, nil, err
+	}
+	if contractAccount == nil || !contractAccount.IsContract() {
+		return nil, nil, nil
+	}
+	var events []*crypto.Event
+	if chargeRent {
+		if events, err = app.chargeRent(contractAccount); err != nil {
+			return nil, nil, err
+		}
+	}
+	if app.prunable(contractAccount) {
+		app.State.DeleteAccount(address)
+		return nil, events, nil
+	}
+	return contractAccount, events, nil
+}
+
+// dormant checks whether contract is behind rent and cannot be executed until topped up
+func (app *App) dormant(contractAccount *storage.Account) bool {
+	return app.rentEnabled() && contractAccount.Dormant(app.Chain.CurrentBlock.Height)
+}
+
+// startRent covers contract deployed in current block for initial period
+func (app *App) startRent(account *storage.Account) {
+	if app.rentEnabled() {
+		account.SettleRent(app.Chain.CurrentBlock.Height + app.genesis.StorageRent.InitialPeriod)
+	}
+}
+
+// settleRent moves paid until height of accounts whose storage size changed,
+// byte blocks paid in advance are kept so growing storage shortens the paid period
+func (app *App) settleRent() {
+	if !app.rentEnabled() {
+		return
+	}
+	height := app.Chain.CurrentBlock.Height
+	for _, account := range app.State.ResizedAccounts() {
+		paidUntil := height
+		if account.PaidUntil > height {
+			hi, lo := bits.Mul64(account.PaidUntil-height, account.SettledRentSize())
+			if size := account.RentSize(); size > 0 && hi < size {
+				blocks, _ := bits.Div64(hi, lo, size)
+				paidUntil += blocks
+			}
+		}
+		account.SettleRent(paidUntil)
+	}
+}
+
+// chargeRent pays rent in arrears of contract from its own balance of gas contract token when it is
+// accessed, contract stays dormant for the blocks it cannot afford
+func (app *App) chargeRent(contractAccount *storage.Account) ([]*crypto.Event, error) {
+	if !app.rentEnabled() {
+		return nil, nil
+	}
+	height := app.Chain.CurrentBlock.Height
+	if !contractAccount.Dormant(height) {
+		return nil, nil
+	}
+	token := app.GetGasContractToken()
+	if token == nil {
+		return nil, nil
+	}
+	hi, blockRent := bits.Mul64(contractAccount.RentSize(), app.genesis.StorageRent.ByteRate)
+	if hi > 0 || blockRent == 0 {
+		return nil, nil
+	}
+
+	balance, err := token.GetBalance(contractAccount.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	blocks := balance / blockRent
+	if owed := height - contractAccount.PaidUntil; blocks > owed {
+		blocks = owed
+	}
+	if blocks == 0 {
+		return nil, nil
+	}
+	events, err := token.Transfer(contractAccount.GetAddress(), gas.FeeCollectorAddress, blocks*blockRent, rentTopUpMemo)
+	if err != nil {
+		return nil, err
+	}
+	contractAccount.SettleRent(contractAccount.PaidUntil + blocks)
+	return events, nil
+}
+
+// topUpRent pays rent of contract from sender in gas contract token, paid amount goes to fee collector
+func (app *App) topUpRent(tx *crypto.Transaction, contractAccount *storage.Account) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+		GasUsed:     uint32(app.gasStation.GetPolicy().GetCostForCall()),
+	}
+
+	senderAddress := tx.SenderAddress()
+	events, err := app.payRent(senderAddress, contractAccount, tx.Payload.Args)
+	if err != nil {
+		receipt.Code = crypto.ReceiptCodeIgniteError
+		app.State.Revert()
+	} else if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		app.State.Revert()
+	} else {
+		receipt.Events = events
+	}
+
+	if err := app.increaseNonce(senderAddress); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
+
+func (app *App) payRent(payer crypto.Address, contractAccount *storage.Account, args []byte) ([]*crypto.Event, error) {
+	decoded, err := abi.DecodeToBytes(rentTopUpParameters, args)
+	if err != nil {
+		return nil, err
+	}
+	amount := binary.LittleEndian.Uint64(decoded[0])
+	if contractAccount.RentSize() == 0 {
+		return nil, errors.New("contract has no storage to pay rent for")
+	}
+	token := app.GetGasContractToken()
+	if token == nil {
+		return nil, errors.New("gas contract is not deployed")
+	}
+
+	events, err := token.Transfer(payer, gas.FeeCollectorAddress, amount, rentTopUpMemo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rent in arrears is paid before extending the period
+	paidUntil := contractAccount.PaidUntil
+	if paidUntil == 0 {
+		paidUntil = app.Chain.CurrentBlock.Height
+	}
+	// Size is read after transfer, which grows storage of contract paying rent in its own token
+	paidUntil += amount / app.genesis.StorageRent.ByteRate / contractAccount.RentSize()
+	contractAccount.SettleRent(paidUntil)
+	return events, nil
+}
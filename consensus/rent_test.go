@@ -0,0 +1,183 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_StorageRent(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"storageRent": {"byteRate": 1, "initialPeriod": 2, "gracePeriod": 2}}`)})
+
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000000000"})
+	deployTx := tr.getPayloadTx(0, deploy)
+	deployTx.Receiver = crypto.EmptyAddress
+	_, privateKey := tr.getSenderWithNonce(0)
+	deployTx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(deployTx).Bytes())
+	senderAddress := crypto.AddressFromPubKey(deployTx.Sender.PublicKey)
+	contractAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	transfer, _ := util.BuildInvokeTxPayload("../test/testdata/gas-token-abi.json", "transfer", []string{"LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53", "100", "0"})
+	amount := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amount, 5000000)
+	topUpArgs, _ := abi.EncodeFromBytes(rentTopUpParameters, [][]byte{amount})
+	topUp := &crypto.TxPayload{ID: rentTopUpFunctionID, Args: topUpArgs}
+
+	appHash := []byte{}
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+	beginBlock := func() {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{AppHash: appHash}})
+	}
+	commit := func() {
+		app.EndBlock(types.RequestEndBlock{})
+		appHash = app.Commit().Data
+	}
+	loadContract := func() *storage.Account {
+		contract, err := app.State.LoadAccount(contractAddress)
+		assert.NoError(t, err)
+		return contract
+	}
+
+	// Deployment is covered for initial period, growing storage shortens it
+	beginBlock()
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(deployTx).Code)
+	contract := loadContract()
+	assert.True(t, contract.StorageSize > 0)
+	assert.Equal(t, uint64(3), contract.PaidUntil)
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(tr.getPayloadTx(1, transfer)).Code)
+	assert.Equal(t, uint64(2), loadContract().PaidUntil)
+	commit()
+
+	beginBlock()
+	commit()
+
+	// Contract behind rent is dormant until topped up
+	beginBlock()
+	// Invocation rejected before execution still pays fee and uses its nonce
+	station := &burnRecordStation{Station: app.gasStation}
+	app.SetGasStation(station)
+	assert.Equal(t, crypto.ReceiptCodeContractDormant, deliver(tr.getPayloadTx(2, transfer)).Code)
+	assert.Equal(t, []crypto.Address{senderAddress}, station.burners)
+	assert.EqualError(t, app.validateTx(tr.getPayloadTx(2, transfer)), "Invalid nonce. Expected 3, got 2")
+	app.SetGasStation(station.Station)
+	app.gasContractAddress = contractAddress.String()
+	receipt := deliver(tr.getPayloadTx(3, topUp))
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, 1, len(receipt.Events))
+	paidUntil := 2 + 5000000/loadContract().RentSize()
+	assert.Equal(t, paidUntil, loadContract().PaidUntil)
+	// Top up of account without anything stored would pay for nothing
+	empty, err := app.State.CreateAccount(senderAddress, crypto.NewDeploymentAddress(senderAddress, 100), nil)
+	assert.NoError(t, err)
+	_, err = app.payRent(senderAddress, empty, topUpArgs)
+	assert.EqualError(t, err, "contract has no storage to pay rent for")
+	commit()
+	app.gasContractAddress = ""
+
+	// Contract is pruned once grace period passes
+	for app.Chain.CurrentBlock.Height <= paidUntil+2 {
+		beginBlock()
+		commit()
+	}
+	beginBlock()
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, deliver(tr.getPayloadTx(4, transfer)).Code)
+	assert.Nil(t, loadContract())
+	assert.EqualError(t, app.validateTx(tr.getPayloadTx(4, transfer)), "Invalid nonce. Expected 5, got 4")
+	commit()
+}
+
+func TestApp_ChargeRent(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"storageRent": {"byteRate": 1, "initialPeriod": 2, "gracePeriod": 1000}}`)})
+
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000000000"})
+	deployTx := tr.getPayloadTx(0, deploy)
+	deployTx.Receiver = crypto.EmptyAddress
+	_, privateKey := tr.getSenderWithNonce(0)
+	deployTx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(deployTx).Bytes())
+	senderAddress := crypto.AddressFromPubKey(deployTx.Sender.PublicKey)
+	contractAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	transfer, _ := util.BuildInvokeTxPayload("../test/testdata/gas-token-abi.json", "transfer", []string{"LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53", "100", "0"})
+
+	appHash := []byte{}
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+	// Gas contract is only set within block so station stays free
+	beginBlock := func() {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{AppHash: appHash}})
+		app.gasContractAddress = contractAddress.String()
+	}
+	commit := func() {
+		app.gasContractAddress = ""
+		app.EndBlock(types.RequestEndBlock{})
+		appHash = app.Commit().Data
+	}
+	loadContract := func() *storage.Account {
+		contract, err := app.State.LoadAccount(contractAddress)
+		assert.NoError(t, err)
+		return contract
+	}
+	contractBalance := func() uint64 {
+		balance, err := app.GetGasContractToken().GetBalance(contractAddress)
+		assert.NoError(t, err)
+		return balance
+	}
+	skipTo := func(height uint64) {
+		for app.Chain.CurrentBlock.Height < height {
+			beginBlock()
+			commit()
+		}
+	}
+
+	// Contract holds enough of its own token for about five blocks
+	beginBlock()
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(deployTx).Code)
+	fund, _ := util.BuildInvokeTxPayload("../test/testdata/gas-token-abi.json", "transfer", []string{contractAddress.String(), strconv.FormatUint(5*loadContract().RentSize(), 10), "0"})
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(tr.getPayloadTx(1, fund)).Code)
+	commit()
+
+	// Rent in arrears is charged on access
+	paidUntil := loadContract().PaidUntil
+	skipTo(paidUntil + 1)
+	beginBlock()
+	balance, size := contractBalance(), loadContract().RentSize()
+	receipt := deliver(tr.getPayloadTx(2, transfer))
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, 2, len(receipt.Events))
+	assert.Equal(t, paidUntil+2, loadContract().PaidUntil)
+	assert.Equal(t, balance-2*size, contractBalance())
+	commit()
+
+	// Contract pays what it can afford and stays dormant
+	paidUntil = loadContract().PaidUntil
+	skipTo(paidUntil + 9)
+	beginBlock()
+	balance, size = contractBalance(), loadContract().RentSize()
+	receipt = deliver(tr.getPayloadTx(3, transfer))
+	assert.Equal(t, crypto.ReceiptCodeContractDormant, receipt.Code)
+	assert.Equal(t, 1, len(receipt.Events))
+	assert.Equal(t, paidUntil+balance/size, loadContract().PaidUntil)
+	assert.Equal(t, balance%size, contractBalance())
+	assert.EqualError(t, app.validateTx(tr.getPayloadTx(3, transfer)), "Invalid nonce. Expected 4, got 3")
+	commit()
+}
@@ -0,0 +1,65 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// validateRewardConfig checks reward address is set by an otherwise empty tx that
+// ed25519 consensus key of a validator sends to its own account
+func validateRewardConfig(tx *crypto.Transaction) error {
+	if tx.Version < 3 {
+		return fmt.Errorf("Reward address requires tx version 3")
+	}
+	if tx.Sender.KeyType != crypto.KeyTypeEd25519 || tx.Multisig != crypto.EmptyAddress {
+		return fmt.Errorf("Reward address is set by ed25519 consensus key of validator")
+	}
+	payload := tx.Payload
+	if tx.Receiver != tx.SenderAddress() || tx.Value > 0 || payload.ID != (crypto.MethodID{}) || len(payload.Args) > 0 || len(payload.Contract) > 0 {
+		return fmt.Errorf("Reward address configuration is an empty tx sent to validator account")
+	}
+	return nil
+}
+
+// rewardAddress returns address receiving fees of validator, address set in state takes precedence over genesis
+func (app *App) rewardAddress(validator []byte) (crypto.Address, bool) {
+	address, ok, err := app.State.RewardAddress(validator)
+	if err != nil {
+		panic(err)
+	}
+	if ok {
+		return address, true
+	}
+	return app.genesis.RewardAddress(validator)
+}
+
+// configureRewardAddress stores reward address of validator sending tx, gas is charged for storing address
+func (app *App) configureRewardAddress(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+		GasUsed:     uint32(app.gasStation.GetPolicy().GetCostForStorage(crypto.AddressLength)),
+	}
+	sender := tx.SenderAddress()
+	if tx.GasLimit < receipt.GasUsed {
+		receipt.GasUsed = tx.GasLimit
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+	} else if err := app.State.SetRewardAddress(crypto.ValidatorAddress(tx.Sender.PublicKey), tx.RewardAddress); err != nil {
+		return nil, err
+	}
+	if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		app.State.Revert()
+	}
+
+	if err := app.increaseNonce(sender); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
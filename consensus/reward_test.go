@@ -0,0 +1,86 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_RewardAddress(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	// Consensus key of validator sends the configuration to its own account
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	validator := crypto.ValidatorAddress(publicKey)
+	genesisReward, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	reward, _ := crypto.AddressFromString("LCR57ROUHIQ2AV4D3E3D7ZBTR6YXMKZQWTI4KSHSWCUCRXBKNJKKBCNY")
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(fmt.Sprintf(`{"feeDistribution": {"rewardAddresses": {
+		"%s": "LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53"
+	}}}`, hex.EncodeToString(validator)))})
+	beginBlock := types.RequestBeginBlock{
+		Header: types.Header{Height: 1, AppHash: []byte{}, ProposerAddress: validator},
+		LastCommitInfo: types.LastCommitInfo{Votes: []types.VoteInfo{
+			{Validator: types.Validator{Address: validator, Power: 10}, SignedLastBlock: true},
+		}},
+	}
+	rewardTx := func(nonce uint64, receiver crypto.Address) *crypto.Transaction {
+		tx := &crypto.Transaction{
+			Version:       3,
+			Sender:        &crypto.TxSender{PublicKey: publicKey, Nonce: nonce},
+			Receiver:      receiver,
+			Payload:       &crypto.TxPayload{},
+			GasPrice:      1,
+			RewardAddress: reward,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+
+	app.BeginBlock(beginBlock)
+	assert.Equal(t, &gas.Distribution{
+		Proposer:        &genesisReward,
+		ProposerPercent: DefaultProposerPercent,
+		Validators:      []*gas.FeeShare{{Address: genesisReward, Power: 10}},
+	}, app.distribution)
+
+	assert.EqualError(t, app.validateTx(rewardTx(0, genesisReward)), "Reward address configuration is an empty tx sent to validator account")
+	rawTx, _ := rewardTx(0, crypto.AddressFromPubKey(publicKey)).Encode()
+	assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+	assert.Equal(t, crypto.ReceiptCodeOK, app.Chain.CurrentBlock.Receipts()[0].Code)
+	app.EndBlock(types.RequestEndBlock{Height: 1})
+	beginBlock.Header.AppHash = app.Commit().Data
+
+	// Reward address set in state takes precedence over genesis
+	beginBlock.Header.Height = 2
+	app.BeginBlock(beginBlock)
+	assert.Equal(t, &gas.Distribution{
+		Proposer:        &reward,
+		ProposerPercent: DefaultProposerPercent,
+		Validators:      []*gas.FeeShare{{Address: reward, Power: 10}},
+	}, app.distribution)
+}
+
+func TestApp_ValidateRewardTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	tx := tr.getInvokeTx(0)
+	tx.RewardAddress = tx.Sender.Address()
+	assert.EqualError(t, app.validateTx(tx), "Reward address requires tx version 3")
+	tx.Version = 3
+	tx.Sender.KeyType = crypto.KeyTypeSecp256k1
+	assert.EqualError(t, app.validateTx(tx), "Reward address is set by ed25519 consensus key of validator")
+	tx.Sender.KeyType = crypto.KeyTypeEd25519
+	tx.Receiver = tx.Sender.Address()
+	assert.EqualError(t, app.validateTx(tx), "Reward address configuration is an empty tx sent to validator account")
+}
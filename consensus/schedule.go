@@ -0,0 +1,147 @@
+package consensus
+
+import (
+	"fmt"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// validateSchedule checks scheduled tx invokes a contract at a later height or time
+// with gas limit fitting scheduled calls of a block
+func (app *App) validateSchedule(tx *crypto.Transaction) error {
+	if tx.Version < 3 {
+		return fmt.Errorf("Schedule requires tx version 3")
+	}
+	if app.genesis.ScheduleGasLimit == 0 {
+		return fmt.Errorf("Scheduling is disabled")
+	}
+	if tx.ScheduleHeight > 0 && tx.ScheduleTime > 0 {
+		return fmt.Errorf("Schedule sets either height or time")
+	}
+	if tx.Receiver == crypto.EmptyAddress || tx.Payload.ID == (crypto.MethodID{}) || tx.IsUpgrade() ||
+		tx.IsMultisigConfig() || app.isRentTopUp(tx) {
+		return fmt.Errorf("Schedule is only set on contract invocation")
+	}
+	if tx.Value > 0 {
+		return fmt.Errorf("Schedule does not accept value")
+	}
+	height, time := uint64(0), uint64(0)
+	if app.Chain.CurrentBlock != nil {
+		height, time = app.Chain.CurrentBlock.Height, app.Chain.CurrentBlock.Time
+	}
+	if tx.ScheduleHeight > 0 && tx.ScheduleHeight <= height {
+		return fmt.Errorf("Schedule height must be after current block")
+	}
+	if tx.ScheduleTime > 0 && tx.ScheduleTime <= time {
+		return fmt.Errorf("Schedule time must be after current block")
+	}
+	if uint64(tx.GasLimit) > app.genesis.ScheduleGasLimit {
+		return fmt.Errorf("Gas limit exceed schedule gas limit %d", app.genesis.ScheduleGasLimit)
+	}
+	return nil
+}
+
+// scheduleCall queues invocation of tx, its whole gas limit is paid now and what remains
+// after storing the call is gas limit of its execution. Result is sequence of scheduled call.
+func (app *App) scheduleCall(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+	}
+	call := &crypto.ScheduledCall{
+		Caller:   tx.SenderAddress(),
+		Receiver: tx.Receiver,
+		ID:       tx.Payload.ID,
+		Args:     tx.Payload.Args,
+		Height:   tx.ScheduleHeight,
+		Time:     tx.ScheduleTime,
+	}
+	encoded, err := call.Encode()
+	if err != nil {
+		return nil, err
+	}
+	receipt.GasUsed = uint32(app.gasStation.GetPolicy().GetCostForStorage(len(encoded)))
+	if tx.GasLimit < receipt.GasUsed {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		return app.chargeGasUsed(tx, &receipt)
+	}
+
+	call.GasLimit = tx.GasLimit - receipt.GasUsed
+	if err := app.State.Schedule(call); err != nil {
+		return nil, err
+	}
+	receipt.Result = call.Sequence
+	receipt.GasUsed = tx.GasLimit
+	if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.Result = 0
+		app.State.Revert()
+	}
+
+	if err := app.increaseNonce(tx.SenderAddress()); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
+
+// runScheduledCalls executes calls due at current block within schedule gas limit. Calls are prepaid so
+// no fee is burnt, each one is dequeued then committed or reverted on its own like a transaction.
+// A call alone exceeding the limit still runs with gas capped by it so it cannot block the queue.
+// Receipt of each call is a system receipt of block referring to the call hash.
+func (app *App) runScheduledCalls() {
+	gasLimit := app.genesis.ScheduleGasLimit
+	if gasLimit == 0 {
+		return
+	}
+	block := app.Chain.CurrentBlock
+	calls, err := app.State.DueCalls(block.Height, block.Time, gasLimit)
+	if err != nil {
+		panic(err)
+	}
+	if len(calls) == 0 {
+		return
+	}
+
+	// Changes made by block before calls are kept when a call reverts
+	app.State.Commit()
+	policy := app.gasStation.GetPolicy()
+	for _, call := range calls {
+		if err := app.State.Unschedule(call); err != nil {
+			panic(err)
+		}
+		app.State.Commit()
+
+		callGasLimit := uint64(call.GasLimit)
+		if callGasLimit > gasLimit {
+			callGasLimit = gasLimit
+		}
+		result, refund, err := app.invokeCall(&crypto.TxCall{Receiver: call.Receiver, ID: call.ID, Args: call.Args}, call.Caller, policy, callGasLimit)
+		if err != nil {
+			panic(err)
+		}
+		if result.Code != crypto.ReceiptCodeOK {
+			app.State.Revert()
+			result.Events = nil
+		} else {
+			// Refund only applies when storage changes are kept
+			result.GasUsed -= uint32(policy.GetRefund(uint64(result.GasUsed), refund))
+			app.settleRent()
+		}
+		receipt := &crypto.Receipt{
+			Transaction: call.Hash(),
+			Result:      result.Result,
+			GasUsed:     result.GasUsed,
+			Code:        result.Code,
+			Events:      result.Events,
+			PostState:   app.State.Commit(),
+		}
+		if err := app.Chain.AddSystemReceipt(receipt); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_ScheduledCall(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"scheduleGasLimit": 1000}`)})
+
+	deployTx := tr.getDeployTx(0)
+	contractAddress := deployTx.DeploymentAddress()
+	senderAddress := deployTx.SenderAddress()
+	mint, _ := util.BuildInvokeTxPayload("../test/testdata/liquid-token-abi.json", "mint", []string{"10"})
+	scheduledTx := func(nonce int, height uint64, gasLimit uint32) *crypto.Transaction {
+		sender, privateKey := tr.getSenderWithNonce(nonce)
+		tx := &crypto.Transaction{
+			Version:        3,
+			Sender:         &sender,
+			Receiver:       contractAddress,
+			Payload:        mint,
+			GasLimit:       gasLimit,
+			GasPrice:       1,
+			ScheduleHeight: height,
+		}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+	balance := func() uint64 {
+		account, _ := app.State.LoadAccount(contractAddress)
+		value, _ := account.GetStorage(senderAddress[:])
+		if len(value) == 0 {
+			return 0
+		}
+		return binary.LittleEndian.Uint64(value)
+	}
+
+	appHash := []byte{}
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+	beginBlock := func() {
+		app.BeginBlock(types.RequestBeginBlock{Header: types.Header{AppHash: appHash}})
+	}
+	commit := func() {
+		app.EndBlock(types.RequestEndBlock{})
+		appHash = app.Commit().Data
+	}
+
+	beginBlock()
+	deliver(deployTx)
+	assert.EqualError(t, app.validateTx(scheduledTx(1, 1, 100)), "Schedule height must be after current block")
+	assert.EqualError(t, app.validateTx(scheduledTx(1, 3, 1001)), "Gas limit exceed schedule gas limit 1000")
+	first := deliver(scheduledTx(1, 3, 600))
+	assert.Equal(t, crypto.ReceiptCodeOK, first.Code)
+	assert.Equal(t, uint64(0), first.Result)
+	second := deliver(scheduledTx(2, 3, 600))
+	assert.Equal(t, uint64(1), second.Result)
+	commit()
+
+	calls, err := app.State.ScheduledCalls()
+	assert.NoError(t, err)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, senderAddress, calls[0].Caller)
+	assert.Equal(t, uint32(600), calls[0].GasLimit)
+
+	beginBlock()
+	commit()
+	assert.Empty(t, app.Chain.CurrentBlock.SystemReceipts())
+
+	// Calls due at height 3 exceed schedule gas limit together, second waits for next block
+	beginBlock()
+	assert.Equal(t, uint64(10), balance())
+	commit()
+	systemReceipts := app.Chain.CurrentBlock.SystemReceipts()
+	assert.Len(t, systemReceipts, 1)
+	assert.Equal(t, calls[0].Hash(), systemReceipts[0].Transaction)
+	assert.Equal(t, crypto.ReceiptCodeOK, systemReceipts[0].Code)
+	assert.Len(t, systemReceipts[0].Events, 1)
+
+	beginBlock()
+	assert.Equal(t, uint64(20), balance())
+	commit()
+	assert.Equal(t, calls[1].Hash(), app.Chain.CurrentBlock.SystemReceipts()[0].Transaction)
+	calls, err = app.State.ScheduledCalls()
+	assert.NoError(t, err)
+	assert.Empty(t, calls)
+
+	// Schedule out of gas is not queued and uses its nonce
+	beginBlock()
+	app.SetGasStation(&policyStation{Station: app.gasStation, policy: gas.NewAlphaPolicy(gas.DefaultSchedule())})
+	assert.Equal(t, crypto.ReceiptCodeOutOfGas, deliver(scheduledTx(3, 10, 1)).Code)
+	assert.EqualError(t, app.validateTx(scheduledTx(3, 10, 1)), "Invalid nonce. Expected 4, got 3")
+	calls, err = app.State.ScheduledCalls()
+	assert.NoError(t, err)
+	assert.Empty(t, calls)
+	commit()
+}
+
+func TestApp_ValidateScheduledTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	tx := tr.getInvokeTx(0)
+	tx.ScheduleHeight = 10
+	assert.EqualError(t, app.validateTx(tx), "Schedule requires tx version 3")
+	tx.Version = 3
+	assert.EqualError(t, app.validateTx(tx), "Scheduling is disabled")
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(`{"scheduleGasLimit": 1000}`)})
+	tx.ScheduleTime = 10
+	assert.EqualError(t, app.validateTx(tx), "Schedule sets either height or time")
+	tx.ScheduleTime = 0
+	tx.Payload = &crypto.TxPayload{}
+	tx.Value = 1
+	assert.EqualError(t, app.validateTx(tx), "Schedule is only set on contract invocation")
+}
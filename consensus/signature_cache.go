@@ -0,0 +1,108 @@
+package consensus
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// maxSignatureCacheSize bounds cached transactions never delivered, oldest one is evicted once reached
+const maxSignatureCacheSize = 20000
+
+// signatureCache remembers transactions whose sender and fee payer signatures are verified,
+// so DeliverTx does not verify again signatures checked in CheckTx or block pre-verification.
+// Tx hash covers signatures so a cached hash always verifies the same way.
+type signatureCache struct {
+	mutex    sync.Mutex
+	verified map[common.Hash]*list.Element
+	order    *list.List
+}
+
+func newSignatureCache() *signatureCache {
+	return &signatureCache{
+		verified: make(map[common.Hash]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (cache *signatureCache) contains(txHash common.Hash) bool {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	_, ok := cache.verified[txHash]
+	return ok
+}
+
+func (cache *signatureCache) add(txHash common.Hash) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if _, ok := cache.verified[txHash]; ok {
+		return
+	}
+	if cache.order.Len() >= maxSignatureCacheSize {
+		oldest := cache.order.Front()
+		cache.order.Remove(oldest)
+		delete(cache.verified, oldest.Value.(common.Hash))
+	}
+	cache.verified[txHash] = cache.order.PushBack(txHash)
+}
+
+func (cache *signatureCache) remove(txHash common.Hash) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.verified[txHash]; ok {
+		cache.order.Remove(element)
+		delete(cache.verified, txHash)
+	}
+}
+
+// SetBlockSource sets lookup of raw transactions of block at height. Tendermint stores the block
+// before executing it, so BeginBlock pre-verifies its transactions before they are delivered one by one.
+func (app *App) SetBlockSource(blockTxs func(height int64) [][]byte) {
+	app.blockTxs = blockTxs
+}
+
+func (app *App) preverifyBlock(height int64) {
+	if app.blockTxs != nil {
+		app.PreverifyTransactions(app.blockTxs(height))
+	}
+}
+
+// PreverifyTransactions verifies signatures of block transactions in parallel
+// and caches valid ones before the block is delivered
+func (app *App) PreverifyTransactions(rawTxs [][]byte) {
+	var txHashes []common.Hash
+	var checks []crypto.SignatureCheck
+	var owners []int
+	for _, rawTx := range rawTxs {
+		tx, err := crypto.DecodeTransaction(rawTx)
+		if err != nil {
+			continue
+		}
+		txHash := tx.Hash()
+		if app.signatures.contains(txHash) {
+			continue
+		}
+		for _, check := range tx.SignatureChecks() {
+			checks = append(checks, check)
+			owners = append(owners, len(txHashes))
+		}
+		txHashes = append(txHashes, txHash)
+	}
+
+	valid := make([]bool, len(txHashes))
+	for i := range valid {
+		valid[i] = true
+	}
+	for i, ok := range crypto.VerifyParallel(checks) {
+		if !ok {
+			valid[owners[i]] = false
+		}
+	}
+	for i, txHash := range txHashes {
+		if valid[i] {
+			app.signatures.add(txHash)
+		}
+	}
+}
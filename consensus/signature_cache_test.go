@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_SignatureCache(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+
+	tx := tr.getDeployTx(0)
+	rawTx, _ := tx.Encode()
+	assert.Equal(t, ResponseCodeOK, app.CheckTx(types.RequestCheckTx{Tx: rawTx}).Code)
+	assert.True(t, app.signatures.contains(tx.Hash()))
+
+	// Cached signature is served once in DeliverTx
+	assert.Equal(t, ResponseCodeOK, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}).Code)
+	assert.False(t, app.signatures.contains(tx.Hash()))
+
+	// Block is pre-verified on BeginBlock, invalid signatures are not cached
+	next := tr.getDeployTx(1)
+	forged := tr.getDeployTx(1)
+	forged.Signature[0]++
+	nextRaw, _ := next.Encode()
+	forgedRaw, _ := forged.Encode()
+	app.SetBlockSource(func(height int64) [][]byte {
+		assert.Equal(t, int64(2), height)
+		return [][]byte{nextRaw, forgedRaw, []byte("invalid")}
+	})
+	app.EndBlock(types.RequestEndBlock{})
+	appHash := app.Commit().Data
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	assert.True(t, app.signatures.contains(next.Hash()))
+	assert.False(t, app.signatures.contains(forged.Hash()))
+	assert.EqualError(t, app.validateTx(forged), "Invalid signature")
+	assert.Equal(t, ResponseCodeOK, app.DeliverTx(types.RequestDeliverTx{Tx: nextRaw}).Code)
+}
+
+func TestSignatureCache_Evict(t *testing.T) {
+	cache := newSignatureCache()
+	hash := func(i uint64) common.Hash {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], i)
+		return common.BytesToHash(b[:])
+	}
+	for i := uint64(0); i < maxSignatureCacheSize; i++ {
+		cache.add(hash(i))
+	}
+	first := hash(0)
+	second := hash(1)
+	cache.remove(second)
+	cache.add(hash(maxSignatureCacheSize))
+	assert.True(t, cache.contains(first))
+
+	// Only oldest entry is evicted once full
+	cache.add(hash(maxSignatureCacheSize + 1))
+	assert.False(t, cache.contains(first))
+	assert.True(t, cache.contains(hash(2)))
+	assert.Equal(t, maxSignatureCacheSize, len(cache.verified))
+}
@@ -0,0 +1,50 @@
+package consensus
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// System event names
+const (
+	GasStationChangedEvent      = "GasStationChanged"
+	GenesisBalanceCreditedEvent = "GenesisBalanceCredited"
+)
+
+// SystemEvents declares events emitted by chain itself, they carry crypto.EmptyAddress as contract
+var SystemEvents = newSystemEvents(
+	&abi.Event{
+		Name: GasStationChangedEvent,
+		Parameters: []*abi.Parameter{
+			{Name: "from", Type: abi.Uint8},
+			{Name: "to", Type: abi.Uint8},
+		},
+	},
+	&abi.Event{
+		Name: GenesisBalanceCreditedEvent,
+		Parameters: []*abi.Parameter{
+			{Name: "address", Type: abi.Address},
+			{Name: "amount", Type: abi.Uint64},
+		},
+	},
+)
+
+func newSystemEvents(events ...*abi.Event) *abi.Header {
+	header := &abi.Header{Events: make(map[crypto.MethodID]*abi.Event)}
+	for _, event := range events {
+		header.Events[crypto.GetMethodID(event.Name)] = event
+	}
+	return header
+}
+
+func newSystemEvent(name string, args ...[]byte) *crypto.Event {
+	event, err := SystemEvents.GetEvent(name)
+	if err != nil {
+		panic(err)
+	}
+	encoded, err := abi.EncodeFromBytes(event.Parameters, args)
+	if err != nil {
+		panic(err)
+	}
+	return &crypto.Event{ID: crypto.GetMethodID(name), Args: encoded, Contract: crypto.EmptyAddress}
+}
@@ -0,0 +1,20 @@
+package consensus
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// addSystemReceipt records events of changes made by block outside of transactions
+// in a system receipt, nothing is recorded when there is no event
+func (app *App) addSystemReceipt(events []*crypto.Event) {
+	if len(events) == 0 {
+		return
+	}
+	receipt := &crypto.Receipt{
+		Events:    events,
+		PostState: app.State.Hash(),
+	}
+	if err := app.Chain.AddSystemReceipt(receipt); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_SystemReceipts(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, _ := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	appState := fmt.Sprintf(`{"balances": {"%s": 1000}, "gasStations": [{"height": 1, "station": "native"}]}`, senderAddress.String())
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(appState)})
+
+	amount := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amount, 1000)
+
+	// Genesis credits and station switch are recorded in a receipt of block beginning
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 1, AppHash: []byte{}}})
+	app.EndBlock(types.RequestEndBlock{Height: 1})
+	appHash := app.Commit().Data
+	block := app.Chain.CurrentBlock
+	assert.Len(t, block.SystemReceipts(), 1)
+	receipt := block.SystemReceipts()[0]
+	assert.Equal(t, common.EmptyHash, receipt.Transaction)
+	assert.Equal(t, uint32(0), receipt.Index)
+	assert.Equal(t, []*crypto.Event{
+		newSystemEvent(GenesisBalanceCreditedEvent, senderAddress[:], amount),
+		newSystemEvent(GasStationChangedEvent, []byte{byte(gas.FreeStationID)}, []byte{byte(gas.NativeStationID)}),
+	}, receipt.Events)
+	assert.NotEqual(t, common.EmptyHash, block.SystemReceiptRoot)
+
+	stored, err := app.Chain.GetSystemReceipts(app.Chain.MustGetBlock(block.Hash()))
+	assert.NoError(t, err)
+	assert.Equal(t, block.SystemReceipts(), stored)
+
+	// Block without changes of its own has no system receipt
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{Height: 2, AppHash: appHash}})
+	app.EndBlock(types.RequestEndBlock{Height: 2})
+	app.Commit()
+	assert.Empty(t, app.Chain.CurrentBlock.SystemReceipts())
+	assert.Equal(t, common.EmptyHash, app.Chain.CurrentBlock.SystemReceiptRoot)
+	stored, err = app.Chain.GetSystemReceipts(app.Chain.CurrentBlock)
+	assert.NoError(t, err)
+	assert.Empty(t, stored)
+}
@@ -8,12 +8,89 @@ import (
 )
 
 func (app *App) validateTx(tx *crypto.Transaction) error {
-	if tx.Version != 1 {
+	if tx.Version < 1 || tx.Version > 3 {
 		return fmt.Errorf("tx version %d not supported", tx.Version)
 	}
+	if err := app.validateReplayProtection(tx); err != nil {
+		return err
+	}
+	if tx.Sender.KeyType != crypto.KeyTypeEd25519 && tx.Version < 3 {
+		return fmt.Errorf("Key type requires tx version 3")
+	}
+	if len(tx.FeePayer) > 0 && tx.Version < 2 {
+		return fmt.Errorf("Fee payer requires tx version 2")
+	}
+	if tx.FeeToken != crypto.EmptyAddress && tx.Version < 2 {
+		return fmt.Errorf("Fee token requires tx version 2")
+	}
+	if tx.Admin != crypto.EmptyAddress {
+		if tx.Version < 2 {
+			return fmt.Errorf("Admin requires tx version 2")
+		}
+		if tx.Receiver != crypto.EmptyAddress {
+			return fmt.Errorf("Admin is only set on deployment")
+		}
+	}
+	if tx.Value > 0 {
+		if tx.Version < 2 {
+			return fmt.Errorf("Value requires tx version 2")
+		}
+		if tx.IsUpgrade() || app.isRentTopUp(tx) {
+			return fmt.Errorf("Value is not accepted by upgrade or rent top up")
+		}
+	}
+	if tx.IsTransfer() && tx.Value == 0 {
+		return fmt.Errorf("Transfer requires value")
+	}
+	if tx.Token != crypto.EmptyAddress && !tx.IsTransfer() {
+		return fmt.Errorf("Token is only set on transfer")
+	}
+	if tx.IsMultiCall() {
+		if err := app.validateMultiCall(tx); err != nil {
+			return err
+		}
+	}
+	if tx.IsScheduled() {
+		if err := app.validateSchedule(tx); err != nil {
+			return err
+		}
+	}
+	if len(tx.Salt) > 0 {
+		if err := app.validateSalt(tx); err != nil {
+			return err
+		}
+	}
+	if tx.IsUpgrade() && tx.Payload.ID != (crypto.MethodID{}) && tx.Payload.ID != migrateFunctionID {
+		return fmt.Errorf("Upgrade only calls %s function", MigrateFunctionName)
+	}
+	// Code of deployment or upgrade must decode even when no function is called
+	if (tx.IsUpgrade() || tx.Receiver == crypto.EmptyAddress && !tx.IsMultiCall()) && tx.Payload.ID == (crypto.MethodID{}) {
+		if _, err := abi.DecodeContract(tx.Payload.Contract); err != nil {
+			return err
+		}
+	}
+	if tx.IsUpgrade() {
+		contractAccount, err := app.State.LoadAccount(tx.Receiver)
+		if err != nil {
+			return err
+		}
+		if contractAccount != nil && contractAccount.IsContract() && !contractAccount.CanUpgrade(tx.SenderAddress()) {
+			return fmt.Errorf("Only admin or creator upgrades contract")
+		}
+	}
+	if tx.IsRewardConfig() {
+		if err := validateRewardConfig(tx); err != nil {
+			return err
+		}
+	}
+	if tx.Multisig != crypto.EmptyAddress || len(tx.Signatures) > 0 || tx.IsMultisigConfig() {
+		if err := validateMultisigFields(tx); err != nil {
+			return err
+		}
+	}
 
 	nonce := uint64(0)
-	address := crypto.AddressFromPubKey(tx.Sender.PublicKey)
+	address := tx.SenderAddress()
 	account, err := app.State.LoadAccount(address)
 	if err != nil {
 		return err
@@ -21,21 +98,45 @@ func (app *App) validateTx(tx *crypto.Transaction) error {
 	if account != nil {
 		nonce = account.Nonce
 	}
+	if tx.Value > 0 && tx.Token == crypto.EmptyAddress && (account == nil || account.Balance < tx.Value) {
+		return fmt.Errorf("Insufficient balance")
+	}
 
 	// Validate tx nonce
 	if tx.Sender.Nonce != nonce {
 		return fmt.Errorf("Invalid nonce. Expected %v, got %v", nonce, tx.Sender.Nonce)
 	}
 
-	// Validate tx signature
+	// Validate tx signature, sender and fee payer signatures verified before are cached
 	signingHash := crypto.GetSigHash(tx)
-	if valid := crypto.VerifySignature(tx.Sender.PublicKey, signingHash.Bytes(), tx.Signature); !valid {
+	txHash := tx.Hash()
+	verified := app.signatures.contains(txHash)
+	if tx.Multisig != crypto.EmptyAddress {
+		keys, threshold, err := multisigPolicy(tx, account)
+		if err != nil {
+			return err
+		}
+		if !crypto.VerifyMultisig(keys, threshold, signingHash.Bytes(), tx.Signatures) {
+			return fmt.Errorf("Invalid multisig signatures")
+		}
+	} else if err := crypto.ValidateKey(tx.Sender.KeyType, tx.Sender.PublicKey); err != nil {
+		return err
+	} else if !verified && !crypto.VerifyKeySignature(tx.Sender.KeyType, tx.Sender.PublicKey, signingHash.Bytes(), tx.Signature) {
 		return fmt.Errorf("Invalid signature")
 	}
+	if len(tx.FeePayer) > 0 {
+		if err := crypto.ValidateKey(crypto.KeyTypeEd25519, tx.FeePayer); err != nil {
+			return err
+		}
+		if !verified && !crypto.VerifySignature(tx.FeePayer, signingHash.Bytes(), tx.FeePayerSignature) {
+			return fmt.Errorf("Invalid fee payer signature")
+		}
+	}
+	app.signatures.add(txHash)
 
 	if tx.Payload.ID != (crypto.MethodID{}) {
 		var contract *abi.Contract
-		if tx.Receiver != crypto.EmptyAddress {
+		if tx.Receiver != crypto.EmptyAddress && !tx.IsUpgrade() {
 			account, err := app.State.LoadAccount(tx.Receiver)
 			if err != nil {
 				return err
@@ -57,20 +158,27 @@ func (app *App) validateTx(tx *crypto.Transaction) error {
 			}
 		}
 
-		function, err := contract.Header.GetFunctionByMethodID(tx.Payload.ID)
-		if err != nil {
-			return err
+		parameters := rentTopUpParameters
+		if !app.isRentTopUp(tx) {
+			function, err := contract.Header.GetFunctionByMethodID(tx.Payload.ID)
+			if err != nil {
+				return err
+			}
+			parameters = function.Parameters
 		}
 
-		_, err = abi.DecodeToBytes(function.Parameters, tx.Payload.Args)
+		_, err = abi.DecodeToBytes(parameters, tx.Payload.Args)
 		if err != nil {
 			return err
 		}
 	}
 
 	// Validate gas limit
+	if app.genesis.BlockGasLimit > 0 && uint64(tx.GasLimit) > app.genesis.BlockGasLimit {
+		return fmt.Errorf("Gas limit exceed block gas limit %d", app.genesis.BlockGasLimit)
+	}
 	fee := uint64(tx.GasLimit) * uint64(tx.GasPrice)
-	if !app.gasStation.Sufficient(address, fee) {
+	if !app.gasStation.Sufficient(tx.Payer(), fee, tx.FeeToken) {
 		return fmt.Errorf("Insufficient fee")
 	}
 
@@ -81,3 +189,63 @@ func (app *App) validateTx(tx *crypto.Transaction) error {
 
 	return nil
 }
+
+// validateSalt checks salted deployment targets an address without live contract
+func (app *App) validateSalt(tx *crypto.Transaction) error {
+	if tx.Version < 3 {
+		return fmt.Errorf("Salt requires tx version 3")
+	}
+	if tx.Receiver != crypto.EmptyAddress {
+		return fmt.Errorf("Salt is only set on deployment")
+	}
+	if len(tx.Salt) > crypto.MaxSaltLength {
+		return fmt.Errorf("Salt exceeds %d bytes", crypto.MaxSaltLength)
+	}
+	address := tx.DeploymentAddress()
+	account, err := app.State.LoadAccount(address)
+	if err != nil {
+		return err
+	}
+	if account != nil && account.IsContract() && !app.prunable(account) {
+		return fmt.Errorf("Contract already deployed at %s", address.String())
+	}
+	return nil
+}
+
+// validateReplayProtection checks version 3 tx is signed for this chain and not expired,
+// older versions are accepted until chain ID is required
+func (app *App) validateReplayProtection(tx *crypto.Transaction) error {
+	height := uint64(0)
+	if app.Chain.CurrentBlock != nil {
+		height = app.Chain.CurrentBlock.Height
+	}
+	if tx.Version < 3 {
+		if len(tx.ChainID) > 0 || tx.Expiry > 0 {
+			return fmt.Errorf("Chain ID and expiry require tx version 3")
+		}
+		if required := app.genesis.ChainIDRequiredFrom; height >= required {
+			return fmt.Errorf("tx version %d not supported from height %d", tx.Version, required)
+		}
+		return nil
+	}
+	if tx.ChainID != app.chainID {
+		return fmt.Errorf("Invalid chain ID. Expected %s, got %s", app.chainID, tx.ChainID)
+	}
+	if tx.Expiry > 0 && height > tx.Expiry {
+		return fmt.Errorf("Transaction expired at height %d", tx.Expiry)
+	}
+	return nil
+}
+
+// fitInBlock checks if tx can be executed without exceeding limits of current block.
+// Gas limit of tx is reserved upfront so the check does not depend on execution result.
+func (app *App) fitInBlock(tx *crypto.Transaction) bool {
+	block := app.Chain.CurrentBlock
+	if app.genesis.BlockGasLimit > 0 && block.GasUsed+uint64(tx.GasLimit) > app.genesis.BlockGasLimit {
+		return false
+	}
+	if app.genesis.BlockMaxTransactions > 0 && app.executedTxs >= app.genesis.BlockMaxTransactions {
+		return false
+	}
+	return true
+}
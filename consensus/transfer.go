@@ -0,0 +1,58 @@
+package consensus
+
+import (
+	"errors"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+const transferMemo = uint64(0)
+
+// transferAsset moves value of transfer tx, in gas contract token if tx names it, otherwise in native balance
+func (app *App) transferAsset(tx *crypto.Transaction) ([]*crypto.Event, error) {
+	senderAddress := tx.SenderAddress()
+	if tx.Token == crypto.EmptyAddress {
+		return nil, app.State.Transfer(senderAddress, tx.Receiver, tx.Value)
+	}
+	token := app.GetGasContractToken()
+	if token == nil || token.GetContract().GetAddress() != tx.Token {
+		return nil, errors.New("token is not gas contract token")
+	}
+	return token.Transfer(senderAddress, tx.Receiver, tx.Value, transferMemo)
+}
+
+// transfer applies transfer tx for fixed gas without executing contract code
+func (app *App) transfer(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+		GasUsed:     uint32(app.gasStation.GetPolicy().GetCostForTransfer()),
+	}
+	if tx.GasLimit < receipt.GasUsed {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		return app.chargeGasUsed(tx, &receipt)
+	}
+
+	events, err := app.transferAsset(tx)
+	if err != nil {
+		receipt.Code = crypto.ReceiptCodeInsufficientBalance
+		app.State.Revert()
+	} else if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		app.State.Revert()
+	} else {
+		receipt.Events = events
+	}
+
+	senderAddress := tx.SenderAddress()
+	if err := app.increaseNonce(senderAddress); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
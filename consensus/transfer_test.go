@@ -0,0 +1,92 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_Transfer(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	sender, privateKey := tr.getSenderWithNonce(0)
+	senderAddress := crypto.AddressFromPubKey(sender.PublicKey)
+	tokenAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	receiver, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	app.InitChain(types.RequestInitChain{AppStateBytes: []byte(fmt.Sprintf(`{"balances": {"%s": 1000}}`, senderAddress.String()))})
+
+	signedTx := func(tx *crypto.Transaction) *crypto.Transaction {
+		tx.Sender = &crypto.TxSender{PublicKey: sender.PublicKey, Nonce: tx.Sender.Nonce}
+		tx.Signature = crypto.Sign(privateKey, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+	transferTx := func(nonce uint64, value uint64, token crypto.Address) *crypto.Transaction {
+		return signedTx(&crypto.Transaction{
+			Version:  2,
+			Sender:   &crypto.TxSender{Nonce: nonce},
+			Receiver: receiver,
+			Payload:  &crypto.TxPayload{},
+			GasLimit: 0,
+			GasPrice: 1,
+			Value:    value,
+			Token:    token,
+		})
+	}
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000"})
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(&crypto.Transaction{
+		Version:  1,
+		Sender:   &crypto.TxSender{Nonce: 0},
+		Payload:  deploy,
+		GasPrice: 1,
+	})).Code)
+
+	// Native balance moves without contract execution
+	assert.True(t, transferTx(1, 400, crypto.EmptyAddress).IsTransfer())
+	legacy := transferTx(1, 0, crypto.EmptyAddress)
+	legacy.Version = 1
+	assert.False(t, legacy.IsTransfer())
+	receipt := deliver(transferTx(1, 400, crypto.EmptyAddress))
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, 0, len(receipt.Events))
+	balance, _ := app.State.GetBalance(receiver)
+	assert.Equal(t, uint64(400), balance)
+	balance, _ = app.State.GetBalance(senderAddress)
+	assert.Equal(t, uint64(600), balance)
+
+	// Gas contract token is moved by token contract
+	app.gasContractAddress = tokenAddress.String()
+	receipt = deliver(transferTx(2, 100, tokenAddress))
+	assert.Equal(t, crypto.ReceiptCodeOK, receipt.Code)
+	assert.Equal(t, 1, len(receipt.Events))
+	tokenBalance, _ := app.GetGasContractToken().GetBalance(receiver)
+	assert.Equal(t, uint64(100), tokenBalance)
+	assert.Equal(t, crypto.ReceiptCodeInsufficientBalance, deliver(transferTx(3, 1000, tokenAddress)).Code)
+	app.gasContractAddress = ""
+
+	assert.EqualError(t, app.validateTx(transferTx(4, 0, crypto.EmptyAddress)), "Transfer requires value")
+	assert.EqualError(t, app.validateTx(transferTx(4, 601, crypto.EmptyAddress)), "Insufficient balance")
+	invoke := tr.getInvokeTx(4)
+	invoke.Version = 2
+	invoke.Token = tokenAddress
+	assert.EqualError(t, app.validateTx(signedTx(invoke)), "Token is only set on transfer")
+
+	// Transfer out of gas is included and uses its nonce
+	app.SetGasStation(&policyStation{Station: app.gasStation, policy: gas.NewAlphaPolicy(gas.DefaultSchedule())})
+	assert.Equal(t, crypto.ReceiptCodeOutOfGas, deliver(transferTx(4, 100, crypto.EmptyAddress)).Code)
+	assert.EqualError(t, app.validateTx(transferTx(4, 100, crypto.EmptyAddress)), "Invalid nonce. Expected 5, got 4")
+}
@@ -0,0 +1,101 @@
+package consensus
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/engine"
+)
+
+// MigrateFunctionName is function of upgraded contract called when upgrade tx invokes it
+const MigrateFunctionName = "migrate"
+
+var (
+	migrateFunctionID = crypto.GetMethodID(MigrateFunctionName)
+)
+
+// upgradeContract replaces code of receiver keeping its storage, only admin or creator may upgrade
+func (app *App) upgradeContract(tx *crypto.Transaction) (*crypto.Receipt, error) {
+	receipt := crypto.Receipt{
+		Transaction: tx.Hash(),
+		FeeToken:    tx.FeeToken,
+	}
+
+	contractAccount, rentEvents, err := app.loadContract(tx.Receiver, true)
+	if err != nil {
+		return nil, err
+	}
+	receipt.Events = rentEvents
+	if contractAccount == nil {
+		receipt.Code = crypto.ReceiptCodeContractNotFound
+		return app.chargeUnexecuted(tx, &receipt)
+	}
+	if app.dormant(contractAccount) {
+		receipt.Code = crypto.ReceiptCodeContractDormant
+		return app.chargeUnexecuted(tx, &receipt)
+	}
+
+	// Also rejected by validateTx, kept so execution never upgrades on behalf of others
+	senderAddress := tx.SenderAddress()
+	if !contractAccount.CanUpgrade(senderAddress) {
+		receipt.Code = crypto.ReceiptCodeUnauthorized
+		return app.chargeUnexecuted(tx, &receipt)
+	}
+
+	policy := app.gasStation.GetPolicy()
+	receipt.GasUsed = uint32(policy.GetCostForContract(len(tx.Payload.Contract)))
+	if tx.GasLimit < receipt.GasUsed {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		return app.chargeGasUsed(tx, &receipt)
+	}
+
+	contract, err := abi.DecodeContract(tx.Payload.Contract)
+	if err != nil {
+		return nil, err
+	}
+	contractAccount.UpgradeContract(tx.Payload.Contract)
+
+	var result uint64
+	var events []*crypto.Event
+	if tx.Payload.ID == migrateFunctionID {
+		function, err := contract.Header.GetFunctionByMethodID(tx.Payload.ID)
+		if err != nil {
+			return nil, err
+		}
+		execEngine := engine.NewEngine(app.State, contractAccount, senderAddress, policy, uint64(tx.GasLimit-receipt.GasUsed))
+		result, err = execEngine.Ignite(function.Name, tx.Payload.Args)
+		receipt.GasUsed += uint32(execEngine.GetGasUsed())
+		if err != nil {
+			receipt.Code = crypto.ReceiptCodeIgniteError
+		} else {
+			// Refund only applies when storage changes are kept
+			receipt.GasUsed -= uint32(policy.GetRefund(uint64(receipt.GasUsed), execEngine.GetRefund()))
+			events = execEngine.GetEvents()
+			// Destructed contracts are deleted before fee is checked, so destructing token paying the fee fails as out of gas
+			app.deleteDestructed(execEngine.GetDestructed())
+		}
+	}
+
+	if receipt.Code == crypto.ReceiptCodeIgniteError {
+		receipt.Events = nil
+		app.State.Revert()
+	} else if !app.gasStation.Sufficient(tx.Payer(), uint64(receipt.GasUsed)*uint64(tx.GasPrice), tx.FeeToken) {
+		receipt.Code = crypto.ReceiptCodeOutOfGas
+		receipt.GasUsed = tx.GasLimit
+		receipt.Events = nil
+		app.State.Revert()
+	} else {
+		receipt.Result = result
+		receipt.Events = append(receipt.Events, events...)
+		app.settleRent()
+	}
+
+	if err := app.increaseNonce(senderAddress); err != nil {
+		return nil, err
+	}
+
+	gasEvents := app.gasStation.Burn(tx.Payer(), uint64(receipt.GasUsed), tx.GasPrice, tx.FeeToken)
+	receipt.Events = append(receipt.Events, gasEvents...)
+	receipt.PostState = app.State.Hash()
+	return &receipt, nil
+}
@@ -0,0 +1,133 @@
+package consensus
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	"github.com/QuoineFinancial/liquid-chain/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/tendermint/tendermint/abci/types"
+)
+
+func TestApp_UpgradeContract(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+	app.InitChain(types.RequestInitChain{})
+
+	_, privateKey := tr.getSenderWithNonce(0)
+	adminSeed := make([]byte, 32)
+	adminSeed[0] = 2
+	adminKey := ed25519.NewKeyFromSeed(adminSeed)
+	adminAddress := crypto.AddressFromPubKey(adminKey.Public().(ed25519.PublicKey))
+
+	signedTx := func(key ed25519.PrivateKey, nonce uint64, receiver crypto.Address, payload *crypto.TxPayload, admin crypto.Address) *crypto.Transaction {
+		tx := &crypto.Transaction{
+			Version:  1,
+			Sender:   &crypto.TxSender{PublicKey: key.Public().(ed25519.PublicKey), Nonce: nonce},
+			Receiver: receiver,
+			Payload:  payload,
+			GasLimit: 0,
+			GasPrice: 1,
+			Admin:    admin,
+		}
+		if admin != crypto.EmptyAddress {
+			tx.Version = 2
+		}
+		tx.Signature = crypto.Sign(key, crypto.GetSigHash(tx).Bytes())
+		return tx
+	}
+
+	deploy, _ := util.BuildDeployTxPayload("../test/testdata/gas-token.wasm", "../test/testdata/gas-token-abi.json", "init", []string{"1000000000"})
+	transfer, _ := util.BuildInvokeTxPayload("../test/testdata/gas-token-abi.json", "transfer", []string{"LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53", "100", "0"})
+	upgrade, _ := util.BuildDeployTxPayload("../test/testdata/liquid-token.wasm", "../test/testdata/liquid-token-abi.json", MigrateFunctionName, nil)
+	assert.Equal(t, crypto.MethodID{}, upgrade.ID)
+
+	// Migrate function declared in header but missing from code fails on ignite
+	contract, _ := abi.DecodeContract(deploy.Contract)
+	contract.Header.Functions[migrateFunctionID] = &abi.Function{Name: MigrateFunctionName}
+	code, _ := rlp.EncodeToBytes(contract)
+	args, _ := abi.EncodeFromBytes(nil, nil)
+	failedMigrate := &crypto.TxPayload{ID: migrateFunctionID, Args: args, Contract: code}
+
+	senderAddress := crypto.AddressFromPubKey(privateKey.Public().(ed25519.PublicKey))
+	contractAddress := crypto.NewDeploymentAddress(senderAddress, 0)
+	administeredAddress := crypto.NewDeploymentAddress(senderAddress, 4)
+
+	deliver := func(tx *crypto.Transaction) *crypto.Receipt {
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, types.ResponseDeliverTx{Code: ResponseCodeOK}, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}))
+		receipts := app.Chain.CurrentBlock.Receipts()
+		return receipts[len(receipts)-1]
+	}
+	reject := func(tx *crypto.Transaction) {
+		assert.EqualError(t, app.validateTx(tx), "Only admin or creator upgrades contract")
+		rawTx, _ := tx.Encode()
+		assert.Equal(t, ResponseCodeNotOK, app.DeliverTx(types.RequestDeliverTx{Tx: rawTx}).Code)
+	}
+	loadContract := func(address crypto.Address) *storage.Account {
+		contract, err := app.State.LoadAccount(address)
+		assert.NoError(t, err)
+		return contract
+	}
+
+	app.BeginBlock(types.RequestBeginBlock{Header: types.Header{}})
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(privateKey, 0, crypto.EmptyAddress, deploy, crypto.EmptyAddress)).Code)
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(privateKey, 1, contractAddress, transfer, crypto.EmptyAddress)).Code)
+	original := *loadContract(contractAddress)
+
+	// Only creator upgrades contract without admin
+	// Unauthorized upgrade is not included, so its nonce stays unused
+	reject(signedTx(adminKey, 0, contractAddress, upgrade, crypto.EmptyAddress))
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(privateKey, 2, contractAddress, upgrade, crypto.EmptyAddress)).Code)
+	upgraded := *loadContract(contractAddress)
+	assert.NotEqual(t, original.ContractHash, upgraded.ContractHash)
+	assert.Equal(t, original.StorageHash, upgraded.StorageHash)
+	assert.Equal(t, []common.Hash{original.ContractHash}, upgraded.CodeHistory)
+
+	// Failed migration keeps current code
+	receipt := deliver(signedTx(privateKey, 3, contractAddress, failedMigrate, crypto.EmptyAddress))
+	assert.Equal(t, crypto.ReceiptCodeIgniteError, receipt.Code)
+	assert.Equal(t, upgraded.ContractHash, loadContract(contractAddress).ContractHash)
+	assert.Equal(t, 1, len(loadContract(contractAddress).CodeHistory))
+
+	// Admin replaces creator
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(privateKey, 4, crypto.EmptyAddress, deploy, adminAddress)).Code)
+	assert.Equal(t, adminAddress, loadContract(administeredAddress).Admin)
+	reject(signedTx(privateKey, 5, administeredAddress, upgrade, crypto.EmptyAddress))
+	// Upgrade of missing contract is included and uses its nonce
+	missing := crypto.NewDeploymentAddress(senderAddress, 9)
+	assert.Equal(t, crypto.ReceiptCodeContractNotFound, deliver(signedTx(privateKey, 5, missing, upgrade, crypto.EmptyAddress)).Code)
+	assert.Equal(t, uint64(6), loadContract(senderAddress).Nonce)
+	assert.Equal(t, crypto.ReceiptCodeOK, deliver(signedTx(adminKey, 0, administeredAddress, upgrade, crypto.EmptyAddress)).Code)
+	assert.Equal(t, 1, len(loadContract(administeredAddress).CodeHistory))
+	app.EndBlock(types.RequestEndBlock{})
+	app.Commit()
+}
+
+func TestApp_ValidateUpgradeTx(t *testing.T) {
+	tr := newAppTestResource()
+	defer tr.cleanData()
+	app := tr.app
+
+	upgrade, _ := util.BuildDeployTxPayload("../test/testdata/liquid-token.wasm", "../test/testdata/liquid-token-abi.json", "", nil)
+	upgrade.ID = crypto.GetMethodID("mint")
+	assert.EqualError(t, app.validateTx(tr.getPayloadTx(0, upgrade)), "Upgrade only calls migrate function")
+
+	// Code that does not decode is rejected before execution
+	broken := &crypto.TxPayload{Contract: []byte{0xde, 0xad}}
+	rawTx, _ := tr.getPayloadTx(0, broken).Encode()
+	assert.Equal(t, ResponseCodeNotOK, app.CheckTx(types.RequestCheckTx{Tx: rawTx}).Code)
+
+	tx := tr.getInvokeTx(0)
+	tx.Version = 2
+	tx.Admin = crypto.NewDeploymentAddress(crypto.EmptyAddress, 0)
+	assert.EqualError(t, app.validateTx(tx), "Admin is only set on deployment")
+	tx.Version = 1
+	assert.EqualError(t, app.validateTx(tx), "Admin requires tx version 2")
+}
@@ -6,15 +6,21 @@ import (
 	"encoding/json"
 
 	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/common"
 	"github.com/QuoineFinancial/liquid-chain/crc16"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/blake2b"
 )
 
 const (
-	versionByteAccountID byte = 11 << 3 // Base32-encodes to 'L...'
+	versionByteAccountID   byte = 11 << 3 // Base32-encodes to 'L...'
+	versionByteContractID  byte = 2 << 3  // Base32-encodes to 'C...'
+	versionByteMultisigID  byte = 12 << 3 // Base32-encodes to 'M...'
+	versionByteSecp256k1ID byte = 18 << 3 // Base32-encodes to 'S...'
 	// AddressLength size of a crypto address
 	AddressLength = 35
+	// MaxSaltLength is maximum size of salt of deterministic deployment
+	MaxSaltLength = 32
 )
 
 // Address crypto address
@@ -42,27 +48,46 @@ func (address *Address) String() string {
 	return base32.StdEncoding.EncodeToString(address[:])
 }
 
-// PubKey retrieves public key of an address
+// IsContract checks whether address is derived for a contract instead of a public key
+func (address *Address) IsContract() bool {
+	return address[0] == versionByteContractID
+}
+
+// IsMultisig checks whether address is derived from keys and threshold of a multisig account
+func (address *Address) IsMultisig() bool {
+	return address[0] == versionByteMultisigID
+}
+
+// PubKey retrieves ed25519 public key of an address, contract, multisig and secp256k1 addresses have none
 func (address *Address) PubKey() (ed25519.PublicKey, error) {
+	if address[0] != versionByteAccountID {
+		return nil, errors.New("address has no public key")
+	}
 	return decodeAddressBytes(address[:])
 }
 
-// AddressFromPubKey create an address from public key
-func AddressFromPubKey(publicKey ed25519.PublicKey) Address {
-	data := append([]byte{versionByteAccountID}, publicKey...)
+func newAddress(version byte, payload []byte) Address {
+	data := append([]byte{version}, payload...)
 	var a Address
 	a.setBytes(append(data, crc16.Checksum(data)...))
 	return a
 }
 
-// AddressFromString parse an address string to Address
+// AddressFromPubKey create an address from public key
+func AddressFromPubKey(publicKey ed25519.PublicKey) Address {
+	return newAddress(versionByteAccountID, publicKey)
+}
+
+// AddressFromString parse an address string to Address, account, contract, multisig and secp256k1 addresses are accepted
 func AddressFromString(address string) (Address, error) {
-	pubKeyBytes, err := decodeAddress(address)
+	raw, err := decodeString(address)
 	if err != nil {
 		return Address{}, err
 	}
-	pubkey := ed25519.PublicKey(pubKeyBytes)
-	return AddressFromPubKey(pubkey), nil
+	if len(raw) != AddressLength {
+		return Address{}, errors.Errorf("encoded value is %d bytes; address length is %d", len(raw), AddressLength)
+	}
+	return AddressFromBytes(raw)
 }
 
 // AddressFromBytes return an address given its bytes
@@ -107,7 +132,9 @@ func decodeAddressBytes(raw []byte) ([]byte, error) {
 	checksum := raw[len(raw)-2:]
 	original := raw[0 : len(raw)-2]
 
-	if version != versionByteAccountID {
+	switch version {
+	case versionByteAccountID, versionByteContractID, versionByteMultisigID, versionByteSecp256k1ID:
+	default:
 		return nil, errors.Errorf("Unexpected version %x", version)
 	}
 
@@ -118,10 +145,18 @@ func decodeAddressBytes(raw []byte) ([]byte, error) {
 	return payload, nil
 }
 
-// NewDeploymentAddress returns new contract deployment address
+// NewDeploymentAddress returns new contract deployment address. Contract addresses used to share version
+// byte of accounts, state of chains deployed before requires a new genesis as their contracts are not found.
 func NewDeploymentAddress(senderAddress Address, senderNonce uint64) Address {
 	senderBytes, _ := rlp.EncodeToBytes([]interface{}{senderAddress, senderNonce})
 	res := blake2b.Sum256(senderBytes)
-	address := AddressFromPubKey(res[:])
-	return address
+	return newAddress(versionByteContractID, res[:])
+}
+
+// NewSaltedDeploymentAddress returns address of contract deployed by creator with salt,
+// it does not depend on nonce so it is known before deployment
+func NewSaltedDeploymentAddress(creator Address, salt []byte, contractHash common.Hash) Address {
+	creatorBytes, _ := rlp.EncodeToBytes([]interface{}{creator, salt, contractHash})
+	res := blake2b.Sum256(creatorBytes)
+	return newAddress(versionByteContractID, res[:])
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/blake2b"
 )
 
 func TestAddressFromString(t *testing.T) {
@@ -112,8 +113,8 @@ func TestAddressFromBytes(t *testing.T) {
 
 func TestNewDeploymentAddress(t *testing.T) {
 	sender, _ := AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
-	contract, _ := AddressFromString("LB5EPP7RST6IROFHLNKTLGKAFQTXGNY45CEAXPTGVT3K53ZXFMMAW575")
-	contract2, _ := AddressFromString("LADAUIL4G5BB6DXOZPG4ES6UHVK4DJND4GADTMW7TDRI4P2B4O7NLJYF")
+	contract, _ := AddressFromString("CB5EPP7RST6IROFHLNKTLGKAFQTXGNY45CEAXPTGVT3K53ZXFMMAWEVQ")
+	contract2, _ := AddressFromString("CADAUIL4G5BB6DXOZPG4ES6UHVK4DJND4GADTMW7TDRI4P2B4O7NLQSI")
 	type args struct {
 		senderAddress Address
 		senderNonce   uint64
@@ -144,6 +145,27 @@ func TestNewDeploymentAddress(t *testing.T) {
 	}
 }
 
+func TestNewSaltedDeploymentAddress(t *testing.T) {
+	creator, _ := AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	codeHash := blake2b.Sum256([]byte{1, 2, 3})
+	address := NewSaltedDeploymentAddress(creator, []byte{1}, codeHash)
+	if address != NewSaltedDeploymentAddress(creator, []byte{1}, codeHash) {
+		t.Errorf("NewSaltedDeploymentAddress() is not deterministic")
+	}
+	if address == NewSaltedDeploymentAddress(creator, []byte{2}, codeHash) || address == NewSaltedDeploymentAddress(creator, []byte{1}, blake2b.Sum256(nil)) {
+		t.Errorf("NewSaltedDeploymentAddress() does not depend on salt and code")
+	}
+	if !address.IsContract() || creator.IsContract() {
+		t.Errorf("IsContract() distinguishes contract from account addresses")
+	}
+	if parsed, err := AddressFromString(address.String()); err != nil || parsed != address {
+		t.Errorf("AddressFromString() = %v, %v, want %v", parsed.String(), err, address.String())
+	}
+	if _, err := address.PubKey(); err == nil {
+		t.Errorf("PubKey() of contract address expect error")
+	}
+}
+
 func TestAddress_setBytes(t *testing.T) {
 	tests := []struct {
 		name string
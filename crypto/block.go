@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"io"
 	"time"
 
 	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
@@ -20,11 +21,12 @@ var GenesisBlock = Block{
 
 // Block is unit of Liquid chain
 type Block struct {
-	hash         common.Hash
-	transactions []*Transaction
-	receipts     []*Receipt
-	txTrie       *trie.Trie
-	receiptTrie  *trie.Trie
+	hash           common.Hash
+	transactions   []*Transaction
+	receipts       []*Receipt
+	systemReceipts []*Receipt
+	txTrie         *trie.Trie
+	receiptTrie    *trie.Trie
 
 	Height          uint64      `json:"height"`
 	Time            uint64      `json:"time"`
@@ -32,6 +34,39 @@ type Block struct {
 	StateRoot       common.Hash `json:"stateRoot"`
 	TransactionRoot common.Hash `json:"transactionRoot"`
 	ReceiptRoot     common.Hash `json:"receiptRoot"`
+
+	// Optional fields
+	GasUsed           uint64      `json:"gasUsed"`
+	BaseFee           uint32      `json:"baseFee"`
+	SystemReceiptRoot common.Hash `json:"systemReceiptRoot"`
+}
+
+func (block *Block) fields() []interface{} {
+	return []interface{}{
+		&block.Height,
+		&block.Time,
+		&block.Parent,
+		&block.StateRoot,
+		&block.TransactionRoot,
+		&block.ReceiptRoot,
+		&block.GasUsed,
+		&block.BaseFee,
+		&block.SystemReceiptRoot,
+	}
+}
+
+// blockFieldCount is number of fields every block encodes
+const blockFieldCount = 6
+
+// EncodeRLP encodes block as a list, trailing empty optional fields are omitted
+// so hash of blocks not using them stays the same
+func (block Block) EncodeRLP(w io.Writer) error {
+	return EncodeFields(w, block.fields(), blockFieldCount)
+}
+
+// DecodeRLP decodes block, missing optional fields are left empty
+func (block *Block) DecodeRLP(s *rlp.Stream) error {
+	return DecodeFields(s, block.fields(), blockFieldCount)
 }
 
 // Transactions returns transactions of block
@@ -44,6 +79,16 @@ func (block *Block) Receipts() []*Receipt {
 	return block.receipts
 }
 
+// SystemReceipts returns receipts of changes made by block itself outside of transactions
+func (block *Block) SystemReceipts() []*Receipt {
+	return block.systemReceipts
+}
+
+// AddSystemReceipts adds receipts of changes made by block itself
+func (block *Block) AddSystemReceipts(receipts ...*Receipt) {
+	block.systemReceipts = append(block.systemReceipts, receipts...)
+}
+
 // AddTransactions adds transactions to block
 func (block *Block) AddTransactions(txs ...*Transaction) {
 	block.transactions = append(block.transactions, txs...)
@@ -69,6 +114,21 @@ func (block *Block) SetReceiptRoot(hash common.Hash) {
 	block.ReceiptRoot.SetBytes(hash.Bytes())
 }
 
+// SetSystemReceiptRoot sets SystemReceiptRoot of block
+func (block *Block) SetSystemReceiptRoot(hash common.Hash) {
+	block.SystemReceiptRoot.SetBytes(hash.Bytes())
+}
+
+// AddGasUsed accumulates gas used by transactions of block
+func (block *Block) AddGasUsed(gas uint64) {
+	block.GasUsed += gas
+}
+
+// SetBaseFee sets BaseFee of block
+func (block *Block) SetBaseFee(baseFee uint32) {
+	block.BaseFee = baseFee
+}
+
 // Hash returns blake2b hash of rlp encoding of block
 func (block *Block) Hash() common.Hash {
 	if block.hash == common.EmptyHash {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
 	"github.com/QuoineFinancial/liquid-chain/common"
 	"github.com/google/go-cmp/cmp"
 )
@@ -42,18 +43,56 @@ func TestBlock(t *testing.T) {
 	block := NewEmptyBlock(common.EmptyHash, 0, time.Unix(0, 0))
 	block.SetStateRoot(common.BytesToHash([]byte{1, 2, 3}))
 	block.SetTransactionRoot(common.BytesToHash([]byte{1, 2, 3}))
+	block.SetBaseFee(18)
+	block.AddGasUsed(100)
+	block.AddGasUsed(50)
+	block.SetSystemReceiptRoot(common.BytesToHash([]byte{4, 5, 6}))
 	encoded, _ := block.Encode()
 	decodedBlock := MustDecodeBlock(encoded)
 	if decodedBlock.Hash() != block.Hash() {
 		t.Errorf("Got block hash after decoded = %v, want %v", decodedBlock.Hash(), block.Hash())
 	}
 
+	if decodedBlock.GasUsed != 150 || decodedBlock.BaseFee != 18 {
+		t.Errorf("Got gas used %v and base fee %v, want %v and %v", decodedBlock.GasUsed, decodedBlock.BaseFee, 150, 18)
+	}
+
+	if decodedBlock.SystemReceiptRoot != common.BytesToHash([]byte{4, 5, 6}) {
+		t.Errorf("Got system receipt root = %v, want %v", decodedBlock.SystemReceiptRoot, common.BytesToHash([]byte{4, 5, 6}))
+	}
+
 	encodedNew, _ := decodedBlock.Encode()
 	if !bytes.Equal(encoded, encodedNew) {
 		t.Errorf("Encode not equal, got = %v, want %v", encodedNew, encoded)
 	}
 }
 
+func TestDecodeLegacyBlock(t *testing.T) {
+	legacy := struct {
+		Height          uint64
+		Time            uint64
+		Parent          common.Hash
+		StateRoot       common.Hash
+		TransactionRoot common.Hash
+		ReceiptRoot     common.Hash
+	}{Height: 1, Time: 123, StateRoot: common.BytesToHash([]byte{1, 2, 3})}
+	encoded, _ := rlp.EncodeToBytes(legacy)
+
+	block, err := DecodeBlock(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Height != 1 || block.Time != 123 || block.StateRoot != legacy.StateRoot {
+		t.Errorf("Got block %v, want fields of %v", block, legacy)
+	}
+	if block.GasUsed != 0 || block.BaseFee != 0 || block.SystemReceiptRoot != common.EmptyHash {
+		t.Errorf("Got optional fields %v, %v, %v, want empty", block.GasUsed, block.BaseFee, block.SystemReceiptRoot)
+	}
+	if reencoded, _ := block.Encode(); !bytes.Equal(encoded, reencoded) {
+		t.Errorf("Encode not equal, got = %v, want %v", reencoded, encoded)
+	}
+}
+
 func TestMustDecodeBlock(t *testing.T) {
 	// This decoding should panic
 	defer func() { recover() }()
@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"io"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/common"
+)
+
+func isEmptyField(field interface{}) bool {
+	switch value := field.(type) {
+	case *[]byte:
+		return len(*value) == 0
+	case *ed25519.PublicKey:
+		return len(*value) == 0
+	case *Address:
+		return *value == EmptyAddress
+	case *uint64:
+		return *value == 0
+	case *uint32:
+		return *value == 0
+	case *common.Hash:
+		return *value == common.EmptyHash
+	case *KeyType:
+		return *value == KeyTypeEd25519
+	case *string:
+		return len(*value) == 0
+	case *[]common.Hash:
+		return len(*value) == 0
+	case *[]ed25519.PublicKey:
+		return len(*value) == 0
+	case *[][]byte:
+		return len(*value) == 0
+	case *[]*TxCall:
+		return len(*value) == 0
+	case *[]*CallResult:
+		return len(*value) == 0
+	}
+	return false
+}
+
+// EncodeFields encodes pointers to fields as a list, empty fields after the required ones
+// are omitted from the tail so adding optional fields keeps existing encodings
+func EncodeFields(w io.Writer, fields []interface{}, required int) error {
+	end := len(fields)
+	for end > required && isEmptyField(fields[end-1]) {
+		end--
+	}
+	return rlp.Encode(w, fields[:end])
+}
+
+// DecodeFields decodes a list into pointers to fields, missing optional fields are left empty
+func DecodeFields(s *rlp.Stream, fields []interface{}, required int) error {
+	if _, err := s.List(); err != nil {
+		return err
+	}
+	for i, field := range fields {
+		if err := s.Decode(field); err == rlp.EOL && i >= required {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return s.ListEnd()
+}
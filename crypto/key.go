@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"golang.org/x/crypto/blake2b"
+)
+
+// KeyType is signature scheme of a transaction sender key
+type KeyType byte
+
+// KeyType values, ed25519 is the default so transactions without key type keep their encoding
+const (
+	KeyTypeEd25519   KeyType = 0x0
+	KeyTypeSecp256k1 KeyType = 0x1
+)
+
+// Secp256k1PublicKeySize is size of compressed secp256k1 public key
+const Secp256k1PublicKeySize = secp256k1.PubKeySecp256k1Size
+
+// AddressFromKey returns address of public key of key type. Secp256k1 keys do not fit
+// address payload so their address is derived from hash of compressed key.
+func AddressFromKey(keyType KeyType, publicKey []byte) Address {
+	if keyType == KeyTypeSecp256k1 {
+		hash := blake2b.Sum256(publicKey)
+		return newAddress(versionByteSecp256k1ID, hash[:])
+	}
+	return AddressFromPubKey(publicKey)
+}
+
+// ValidateKey checks public key has the size of its key type
+func ValidateKey(keyType KeyType, publicKey []byte) error {
+	switch keyType {
+	case KeyTypeEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return errors.Errorf("invalid ed25519 key length %d", len(publicKey))
+		}
+	case KeyTypeSecp256k1:
+		if len(publicKey) != Secp256k1PublicKeySize {
+			return errors.Errorf("invalid secp256k1 key length %d", len(publicKey))
+		}
+	default:
+		return errors.Errorf("unsupported key type %d", keyType)
+	}
+	return nil
+}
+
+// VerifyKeySignature verifies signature of message by public key of key type
+func VerifyKeySignature(keyType KeyType, publicKey, message, signature []byte) bool {
+	if ValidateKey(keyType, publicKey) != nil {
+		return false
+	}
+	if keyType == KeyTypeSecp256k1 {
+		var key secp256k1.PubKeySecp256k1
+		copy(key[:], publicKey)
+		return key.VerifyBytes(message, signature)
+	}
+	return VerifySignature(publicKey, message, signature)
+}
+
+// Secp256k1PublicKey returns compressed public key of 32 bytes secp256k1 private key
+func Secp256k1PublicKey(privateKey []byte) []byte {
+	var key secp256k1.PrivKeySecp256k1
+	copy(key[:], privateKey)
+	publicKey := key.PubKey().(secp256k1.PubKeySecp256k1)
+	return publicKey[:]
+}
+
+// SignSecp256k1 returns signature of message by 32 bytes secp256k1 private key in R || S form
+func SignSecp256k1(privateKey []byte, message []byte) ([]byte, error) {
+	var key secp256k1.PrivKeySecp256k1
+	copy(key[:], privateKey)
+	return key.Sign(message)
+}
@@ -0,0 +1,58 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+)
+
+func TestSecp256k1Sender(t *testing.T) {
+	privateKey := make([]byte, 32)
+	privateKey[0] = 1
+	tx := &Transaction{
+		Version: 3,
+		Sender: &TxSender{
+			PublicKey: Secp256k1PublicKey(privateKey),
+			KeyType:   KeyTypeSecp256k1,
+		},
+		Payload: &TxPayload{Args: []byte{1}},
+	}
+	signature, err := SignSecp256k1(privateKey, GetSigHash(tx).Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Signature = signature
+	if !VerifyKeySignature(tx.Sender.KeyType, tx.Sender.PublicKey, GetSigHash(tx).Bytes(), tx.Signature) {
+		t.Errorf("VerifyKeySignature() of secp256k1 signature = false")
+	}
+	if VerifyKeySignature(KeyTypeEd25519, tx.Sender.PublicKey, GetSigHash(tx).Bytes(), tx.Signature) {
+		t.Errorf("VerifyKeySignature() of secp256k1 key as ed25519 = true")
+	}
+
+	address := tx.SenderAddress()
+	if parsed, err := AddressFromString(address.String()); err != nil || parsed != address {
+		t.Errorf("AddressFromString() = %v, %v, want %v", parsed.String(), err, address.String())
+	}
+	if _, err := address.PubKey(); err == nil {
+		t.Errorf("PubKey() of secp256k1 address expect error")
+	}
+
+	encoded, _ := tx.Encode()
+	decoded, err := DecodeTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Sender.KeyType != KeyTypeSecp256k1 || decoded.SenderAddress() != address {
+		t.Errorf("DecodeTransaction() sender = %v, want %v", decoded.Sender, tx.Sender)
+	}
+
+	// Ed25519 sender keeps encoding without key type
+	edKey := ed25519.NewKeyFromSeed(privateKey).Public().(ed25519.PublicKey)
+	legacy, _ := rlp.EncodeToBytes([]interface{}{edKey, uint64(1)})
+	encoded, _ = rlp.EncodeToBytes(TxSender{PublicKey: edKey, Nonce: 1})
+	if !bytes.Equal(legacy, encoded) {
+		t.Errorf("Encode() of ed25519 sender = %x, want %x", encoded, legacy)
+	}
+}
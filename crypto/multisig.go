@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"sort"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// MaxMultisigKeys is maximum number of keys controlling a multisig account
+const MaxMultisigKeys = 16
+
+// SortKeys returns keys in ascending byte order, multisig policies keep keys sorted
+func SortKeys(keys []ed25519.PublicKey) []ed25519.PublicKey {
+	sorted := make([]ed25519.PublicKey, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i], sorted[j]) < 0
+	})
+	return sorted
+}
+
+// ValidateMultisigPolicy checks keys are sorted without duplicates and threshold is reachable
+func ValidateMultisigPolicy(keys []ed25519.PublicKey, threshold uint64) error {
+	if len(keys) == 0 || len(keys) > MaxMultisigKeys {
+		return errors.Errorf("multisig requires 1 to %d keys", MaxMultisigKeys)
+	}
+	if threshold == 0 || threshold > uint64(len(keys)) {
+		return errors.Errorf("multisig threshold must be between 1 and %d", len(keys))
+	}
+	for i, key := range keys {
+		if len(key) != ed25519.PublicKeySize {
+			return errors.Errorf("invalid multisig key length %d", len(key))
+		}
+		if i > 0 && bytes.Compare(keys[i-1], key) >= 0 {
+			return errors.New("multisig keys must be sorted and unique")
+		}
+	}
+	return nil
+}
+
+// NewMultisigAddress returns address of account controlled by threshold of sorted keys
+func NewMultisigAddress(keys []ed25519.PublicKey, threshold uint64) Address {
+	policyBytes, _ := rlp.EncodeToBytes([]interface{}{threshold, SortKeys(keys)})
+	res := blake2b.Sum256(policyBytes)
+	return newAddress(versionByteMultisigID, res[:])
+}
+
+// VerifyMultisig checks signatures given by key position reach threshold,
+// empty signatures are skipped and any invalid one fails verification
+func VerifyMultisig(keys []ed25519.PublicKey, threshold uint64, message []byte, signatures [][]byte) bool {
+	if len(signatures) > len(keys) {
+		return false
+	}
+	signed := uint64(0)
+	for i, signature := range signatures {
+		if len(signature) == 0 {
+			continue
+		}
+		if !VerifySignature(keys[i], message, signature) {
+			return false
+		}
+		signed++
+	}
+	return signed >= threshold
+}
@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"testing"
+)
+
+func TestVerifyMultisig(t *testing.T) {
+	var privateKeys []ed25519.PrivateKey
+	var keys []ed25519.PublicKey
+	for i := byte(0); i < 3; i++ {
+		seed := make([]byte, 32)
+		seed[0] = i
+		privateKey := ed25519.NewKeyFromSeed(seed)
+		privateKeys = append(privateKeys, privateKey)
+		keys = append(keys, privateKey.Public().(ed25519.PublicKey))
+	}
+	if err := ValidateMultisigPolicy(keys, 2); err == nil {
+		t.Errorf("ValidateMultisigPolicy() of unsorted keys expect error")
+	}
+	if err := ValidateMultisigPolicy(SortKeys(keys), 2); err != nil {
+		t.Errorf("ValidateMultisigPolicy() err = %v", err)
+	}
+
+	message := []byte("message")
+	signatures := [][]byte{Sign(privateKeys[0], message), nil, Sign(privateKeys[2], message)}
+	tests := []struct {
+		name       string
+		threshold  uint64
+		signatures [][]byte
+		want       bool
+	}{
+		{"threshold reached", 2, signatures, true},
+		{"threshold not reached", 3, signatures, false},
+		{"invalid signature", 1, [][]byte{signatures[2]}, false},
+		{"too many signatures", 1, append(signatures, signatures[0]), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyMultisig(keys, tt.threshold, message, tt.signatures); got != tt.want {
+				t.Errorf("VerifyMultisig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
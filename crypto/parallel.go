@@ -0,0 +1,74 @@
+package crypto
+
+import (
+	"runtime"
+	"sync"
+)
+
+// SignatureCheck is a signature of message to be verified by public key of key type
+type SignatureCheck struct {
+	KeyType   KeyType
+	PublicKey []byte
+	Message   []byte
+	Signature []byte
+}
+
+// Verify verifies signature of check
+func (check SignatureCheck) Verify() bool {
+	return VerifyKeySignature(check.KeyType, check.PublicKey, check.Message, check.Signature)
+}
+
+// VerifyParallel verifies checks in parallel on every CPU and returns validity of each check.
+// Each check is verified on its own instead of by a random linear combination of the batch:
+// combined verification accepts some signatures with small order components that single
+// verification rejects, so nodes caching its result could disagree on the same transaction.
+func VerifyParallel(checks []SignatureCheck) []bool {
+	results := make([]bool, len(checks))
+	workers := runtime.NumCPU()
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+
+	indexes := make(chan int, len(checks))
+	for i := range checks {
+		indexes <- i
+	}
+	close(indexes)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = checks[i].Verify()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// SignatureChecks returns sender and fee payer signature checks of tx.
+// Multisig signatures depend on account policy so they are not included.
+func (tx *Transaction) SignatureChecks() []SignatureCheck {
+	var checks []SignatureCheck
+	signingHash := GetSigHash(tx).Bytes()
+	if tx.Multisig == EmptyAddress {
+		checks = append(checks, SignatureCheck{
+			KeyType:   tx.Sender.KeyType,
+			PublicKey: tx.Sender.PublicKey,
+			Message:   signingHash,
+			Signature: tx.Signature,
+		})
+	}
+	if len(tx.FeePayer) > 0 {
+		checks = append(checks, SignatureCheck{
+			KeyType:   KeyTypeEd25519,
+			PublicKey: tx.FeePayer,
+			Message:   signingHash,
+			Signature: tx.FeePayerSignature,
+		})
+	}
+	return checks
+}
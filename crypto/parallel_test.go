@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"reflect"
+	"testing"
+)
+
+func TestVerifyParallel(t *testing.T) {
+	var checks []SignatureCheck
+	for i := byte(0); i < 8; i++ {
+		seed := make([]byte, 32)
+		seed[0] = i
+		privateKey := ed25519.NewKeyFromSeed(seed)
+		message := []byte{i}
+		checks = append(checks, SignatureCheck{
+			KeyType:   KeyTypeEd25519,
+			PublicKey: privateKey.Public().(ed25519.PublicKey),
+			Message:   message,
+			Signature: Sign(privateKey, message),
+		})
+	}
+	checks[3].Message = []byte{0xff}
+	checks[5].KeyType = KeyTypeSecp256k1
+
+	want := []bool{true, true, true, false, true, false, true, true}
+	if got := VerifyParallel(checks); !reflect.DeepEqual(got, want) {
+		t.Errorf("VerifyParallel() = %v, want %v", got, want)
+	}
+	if got := VerifyParallel(nil); len(got) != 0 {
+		t.Errorf("VerifyParallel(nil) = %v, want empty", got)
+	}
+}
+
+func TestTransaction_SignatureChecks(t *testing.T) {
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	tx := &Transaction{
+		Version:  2,
+		Sender:   &TxSender{PublicKey: privateKey.Public().(ed25519.PublicKey)},
+		Payload:  &TxPayload{},
+		FeePayer: privateKey.Public().(ed25519.PublicKey),
+	}
+	tx.Signature = Sign(privateKey, GetSigHash(tx).Bytes())
+	tx.FeePayerSignature = Sign(privateKey, GetSigHash(tx).Bytes())
+	if got := VerifyParallel(tx.SignatureChecks()); !reflect.DeepEqual(got, []bool{true, true}) {
+		t.Errorf("VerifyParallel() of sender and fee payer = %v", got)
+	}
+
+	tx.Multisig = NewMultisigAddress([]ed25519.PublicKey{tx.FeePayer}, 1)
+	if checks := tx.SignatureChecks(); len(checks) != 1 || !reflect.DeepEqual(checks[0].PublicKey, []byte(tx.FeePayer)) {
+		t.Errorf("SignatureChecks() of multisig tx = %v, want fee payer only", checks)
+	}
+}
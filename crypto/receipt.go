@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"io"
+
 	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
 	"github.com/QuoineFinancial/liquid-chain/common"
 	"golang.org/x/crypto/blake2b"
@@ -13,7 +15,8 @@ type Event struct {
 	Contract Address  `json:"contract"`
 }
 
-// Receipt reflects corresponding Transaction execution result
+// Receipt reflects corresponding Transaction execution result. System receipts of block record changes
+// made outside of transactions, they refer to scheduled call they execute or to no transaction at all.
 type Receipt struct {
 	Transaction common.Hash
 	Index       uint32      `json:"index"`
@@ -22,6 +25,45 @@ type Receipt struct {
 	Code        ReceiptCode `json:"code"`
 	Events      []*Event    `json:"events"`
 	PostState   common.Hash
+
+	// Optional fields
+	FeeToken Address       `json:"feeToken"`
+	Calls    []*CallResult `json:"calls,omitempty"`
+}
+
+// CallResult reflects execution of a call of multi-call transaction, its events are kept only if every call succeeds
+type CallResult struct {
+	Code    ReceiptCode `json:"code"`
+	Result  uint64      `json:"result"`
+	GasUsed uint32      `json:"gasUsed"`
+	Events  []*Event    `json:"events"`
+}
+
+func (receipt *Receipt) fields() []interface{} {
+	return []interface{}{
+		&receipt.Transaction,
+		&receipt.Index,
+		&receipt.Result,
+		&receipt.GasUsed,
+		&receipt.Code,
+		&receipt.Events,
+		&receipt.PostState,
+		&receipt.FeeToken,
+		&receipt.Calls,
+	}
+}
+
+// receiptFieldCount is number of fields every receipt encodes
+const receiptFieldCount = 7
+
+// EncodeRLP encodes receipt as a list, trailing empty optional fields are omitted
+func (receipt Receipt) EncodeRLP(w io.Writer) error {
+	return EncodeFields(w, receipt.fields(), receiptFieldCount)
+}
+
+// DecodeRLP decodes receipt, missing optional fields are left empty
+func (receipt *Receipt) DecodeRLP(s *rlp.Stream) error {
+	return DecodeFields(s, receipt.fields(), receiptFieldCount)
 }
 
 // Encode returns bytes representation of transaction
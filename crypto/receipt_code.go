@@ -5,9 +5,13 @@ type ReceiptCode byte
 
 // ReceiptCode values
 const (
-	ReceiptCodeOK               ReceiptCode = 0x0
-	ReceiptCodeOutOfGas         ReceiptCode = 0x1
-	ReceiptCodeIgniteError      ReceiptCode = 0x2
-	ReceiptCodeContractNotFound ReceiptCode = 0x3
-	ReceiptCodeMethodNotFound   ReceiptCode = 0x4
+	ReceiptCodeOK                  ReceiptCode = 0x0
+	ReceiptCodeOutOfGas            ReceiptCode = 0x1
+	ReceiptCodeIgniteError         ReceiptCode = 0x2
+	ReceiptCodeContractNotFound    ReceiptCode = 0x3
+	ReceiptCodeMethodNotFound      ReceiptCode = 0x4
+	ReceiptCodeBlockLimitExceeded  ReceiptCode = 0x5
+	ReceiptCodeContractDormant     ReceiptCode = 0x6
+	ReceiptCodeUnauthorized        ReceiptCode = 0x7
+	ReceiptCodeInsufficientBalance ReceiptCode = 0x8
 )
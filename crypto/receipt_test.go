@@ -0,0 +1,40 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/common"
+)
+
+func TestReceipt_Encode(t *testing.T) {
+	receipt := Receipt{
+		Transaction: common.BytesToHash([]byte{1}),
+		Index:       2,
+		Result:      3,
+		GasUsed:     4,
+		Code:        ReceiptCodeOK,
+		Events:      []*Event{{Args: []byte{5}}},
+		PostState:   common.BytesToHash([]byte{6}),
+	}
+
+	// Receipt without optional fields keeps encoding of plain struct
+	legacy, _ := rlp.EncodeToBytes([]interface{}{
+		receipt.Transaction, receipt.Index, receipt.Result, receipt.GasUsed, receipt.Code, receipt.Events, receipt.PostState,
+	})
+	encoded, _ := receipt.Encode()
+	if !bytes.Equal(encoded, legacy) {
+		t.Errorf("Receipt.Encode() = %v, want %v", encoded, legacy)
+	}
+
+	receipt.FeeToken = NewDeploymentAddress(Address{}, 1)
+	encoded, _ = receipt.Encode()
+	decoded, err := DecodeReceipt(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != receipt.Hash() || decoded.FeeToken != receipt.FeeToken {
+		t.Errorf("DecodeReceipt() = %v, want %v", decoded, receipt)
+	}
+}
@@ -0,0 +1,19 @@
+package crypto
+
+import (
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	"golang.org/x/crypto/blake2b"
+)
+
+// RewardRegistryAddress is system account keeping reward addresses set by validators in its storage
+var RewardRegistryAddress = newRewardRegistryAddress()
+
+func newRewardRegistryAddress() Address {
+	payload := blake2b.Sum256([]byte("reward registry"))
+	return newAddress(versionByteContractID, payload[:])
+}
+
+// ValidatorAddress returns Tendermint validator address of ed25519 consensus key
+func ValidatorAddress(publicKey []byte) []byte {
+	return tmhash.SumTruncated(publicKey)
+}
@@ -0,0 +1,48 @@
+package crypto
+
+import (
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ScheduleAddress is system account keeping scheduled calls in its storage, no key or code controls it
+var ScheduleAddress = newScheduleAddress()
+
+func newScheduleAddress() Address {
+	payload := blake2b.Sum256([]byte("schedule"))
+	return newAddress(versionByteContractID, payload[:])
+}
+
+// ScheduledCall is a contract call prepaid by its caller, it is executed at the start of the first block
+// reaching its due height, or its due time when height is zero. Sequence orders calls due at the same point.
+type ScheduledCall struct {
+	Sequence uint64   `json:"sequence"`
+	Caller   Address  `json:"caller"`
+	Receiver Address  `json:"receiver"`
+	ID       MethodID `json:"signature"`
+	Args     []byte   `json:"args"`
+	Height   uint64   `json:"height"`
+	Time     uint64   `json:"time"`
+	GasLimit uint32   `json:"gasLimit"`
+}
+
+// Encode returns bytes representation of scheduled call
+func (call ScheduledCall) Encode() ([]byte, error) {
+	return rlp.EncodeToBytes(call)
+}
+
+// DecodeScheduledCall returns ScheduledCall from bytes representation
+func DecodeScheduledCall(raw []byte) (*ScheduledCall, error) {
+	var call ScheduledCall
+	if err := rlp.DecodeBytes(raw, &call); err != nil {
+		return nil, err
+	}
+	return &call, nil
+}
+
+// Hash returns hash of scheduled call, its receipt refers to it as transaction
+func (call ScheduledCall) Hash() common.Hash {
+	encoded, _ := call.Encode()
+	return blake2b.Sum256(encoded)
+}
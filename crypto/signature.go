@@ -13,8 +13,17 @@ func Sign(privateKey ed25519.PrivateKey, message []byte) []byte {
 	return ed25519.Sign(privateKey, message)
 }
 
-// GetSigHash returns hash for signing transaction
+// GetSigHash returns hash for signing transaction.
+// From version 2, it covers every field except signatures so sender, fee payer and multisig keys sign the same hash.
 func GetSigHash(tx *Transaction) common.Hash {
+	if tx.Version >= 2 {
+		unsigned := *tx
+		unsigned.Signature = nil
+		unsigned.FeePayerSignature = nil
+		unsigned.Signatures = nil
+		encoded, _ := unsigned.Encode()
+		return blake2b.Sum256(encoded)
+	}
 	encoded, _ := rlp.EncodeToBytes([]interface{}{
 		tx.Version,
 		tx.Sender,
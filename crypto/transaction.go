@@ -2,23 +2,74 @@ package crypto
 
 import (
 	"crypto/ed25519"
+	"io"
 
 	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
 	"github.com/QuoineFinancial/liquid-chain/common"
 	"golang.org/x/crypto/blake2b"
 )
 
-// TxSender is sender of transaction
+// TxSender is sender of transaction, its key is ed25519 unless KeyType tells otherwise
 type TxSender struct {
-	PublicKey ed25519.PublicKey `json:"publicKey"`
-	Nonce     uint64            `json:"nonce"`
+	PublicKey []byte  `json:"publicKey"`
+	Nonce     uint64  `json:"nonce"`
+	KeyType   KeyType `json:"keyType,omitempty"`
 }
 
-// TxPayload contains data to interact with smart contract
+// senderFieldCount is number of fields every sender encodes
+const senderFieldCount = 2
+
+// EncodeRLP encodes sender as a list, ed25519 key type is omitted so existing encodings stay the same
+func (sender TxSender) EncodeRLP(w io.Writer) error {
+	return EncodeFields(w, []interface{}{&sender.PublicKey, &sender.Nonce, &sender.KeyType}, senderFieldCount)
+}
+
+// DecodeRLP decodes sender, missing key type is ed25519
+func (sender *TxSender) DecodeRLP(s *rlp.Stream) error {
+	return DecodeFields(s, []interface{}{&sender.PublicKey, &sender.Nonce, &sender.KeyType}, senderFieldCount)
+}
+
+// Address returns address of sender key
+func (sender *TxSender) Address() Address {
+	return AddressFromKey(sender.KeyType, sender.PublicKey)
+}
+
+// TxPayload contains data to interact with smart contract.
+// Multi-call payload leaves method and contract empty and lists its calls instead.
 type TxPayload struct {
 	ID       MethodID `json:"signature"`
 	Args     []byte   `json:"args"`
 	Contract []byte   `json:"contract"`
+
+	// Optional fields, available from version 3
+	Calls []*TxCall `json:"calls,omitempty"`
+}
+
+// TxCall is a contract call of multi-call payload
+type TxCall struct {
+	Receiver Address  `json:"receiver"`
+	ID       MethodID `json:"signature"`
+	Args     []byte   `json:"args"`
+}
+
+// MaxPayloadCalls is maximum number of calls in multi-call payload
+const MaxPayloadCalls = 16
+
+// payloadFieldCount is number of fields every payload encodes
+const payloadFieldCount = 3
+
+func (payload *TxPayload) fields() []interface{} {
+	return []interface{}{&payload.ID, &payload.Args, &payload.Contract, &payload.Calls}
+}
+
+// EncodeRLP encodes payload as a list, empty calls are omitted so single call payloads keep their encoding
+func (payload TxPayload) EncodeRLP(w io.Writer) error {
+	return EncodeFields(w, payload.fields(), payloadFieldCount)
+}
+
+// DecodeRLP decodes payload, missing calls are left empty
+func (payload *TxPayload) DecodeRLP(s *rlp.Stream) error {
+	return DecodeFields(s, payload.fields(), payloadFieldCount)
 }
 
 // Transaction is transaction of liquid-chain
@@ -30,6 +81,76 @@ type Transaction struct {
 	GasPrice  uint32     `json:"gasPrice"`
 	GasLimit  uint32     `json:"gasLimit"`
 	Signature []byte     `json:"signature"`
+
+	// Optional fields, available from version 2
+	FeePayer          ed25519.PublicKey `json:"feePayer,omitempty"`
+	FeePayerSignature []byte            `json:"feePayerSignature,omitempty"`
+	FeeToken          Address           `json:"feeToken,omitempty"`
+	Admin             Address           `json:"admin,omitempty"`
+	Value             uint64            `json:"value,omitempty"`
+	Token             Address           `json:"token,omitempty"`
+
+	// Optional fields, available from version 3
+	ChainID string `json:"chainId,omitempty"`
+	Expiry  uint64 `json:"expiry,omitempty"`
+	Salt    []byte `json:"salt,omitempty"`
+
+	// Multisig sends tx instead of Sender key, Signatures are given by position of its keys.
+	// MultisigKeys and MultisigThreshold configure the account on a transaction sent to itself.
+	Multisig          Address             `json:"multisig,omitempty"`
+	Signatures        [][]byte            `json:"signatures,omitempty"`
+	MultisigKeys      []ed25519.PublicKey `json:"multisigKeys,omitempty"`
+	MultisigThreshold uint64              `json:"multisigThreshold,omitempty"`
+
+	// Invocation with schedule height or time is queued and executed once block reaches it
+	ScheduleHeight uint64 `json:"scheduleHeight,omitempty"`
+	ScheduleTime   uint64 `json:"scheduleTime,omitempty"`
+
+	// RewardAddress receives fees of the validator whose consensus key sends tx to itself
+	RewardAddress Address `json:"rewardAddress,omitempty"`
+}
+
+// fields returns pointers to fields in encoding order, v1 fields come first
+func (tx *Transaction) fields() []interface{} {
+	return []interface{}{
+		&tx.Version,
+		&tx.Sender,
+		&tx.Receiver,
+		&tx.Payload,
+		&tx.GasPrice,
+		&tx.GasLimit,
+		&tx.Signature,
+		&tx.FeePayer,
+		&tx.FeePayerSignature,
+		&tx.FeeToken,
+		&tx.Admin,
+		&tx.Value,
+		&tx.Token,
+		&tx.ChainID,
+		&tx.Expiry,
+		&tx.Salt,
+		&tx.Multisig,
+		&tx.Signatures,
+		&tx.MultisigKeys,
+		&tx.MultisigThreshold,
+		&tx.ScheduleHeight,
+		&tx.ScheduleTime,
+		&tx.RewardAddress,
+	}
+}
+
+// v1FieldCount is number of fields every transaction encodes
+const v1FieldCount = 7
+
+// EncodeRLP encodes transaction as a list, trailing empty optional fields are omitted
+// so encoding of transactions not using them stays the same
+func (tx Transaction) EncodeRLP(w io.Writer) error {
+	return EncodeFields(w, tx.fields(), v1FieldCount)
+}
+
+// DecodeRLP decodes transaction, missing optional fields are left empty
+func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
+	return DecodeFields(s, tx.fields(), v1FieldCount)
 }
 
 // Encode returns bytes representation of transaction
@@ -37,6 +158,63 @@ func (tx Transaction) Encode() ([]byte, error) {
 	return rlp.EncodeToBytes(tx)
 }
 
+// SenderAddress returns address sending transaction, it is multisig account if set, otherwise address of sender key
+func (tx *Transaction) SenderAddress() Address {
+	if tx.Multisig != EmptyAddress {
+		return tx.Multisig
+	}
+	return tx.Sender.Address()
+}
+
+// Payer returns address paying fee of transaction, it is sender unless fee payer is set
+func (tx *Transaction) Payer() Address {
+	if len(tx.FeePayer) > 0 {
+		return AddressFromPubKey(tx.FeePayer)
+	}
+	return tx.SenderAddress()
+}
+
+// IsTransfer checks whether tx only moves value to receiver, transfers carry empty payload.
+// Value is available from version 2, so version 1 txs with empty payload stay invocations.
+func (tx *Transaction) IsTransfer() bool {
+	return tx.Version >= 2 && tx.Receiver != EmptyAddress && tx.Payload != nil && !tx.IsMultisigConfig() && !tx.IsMultiCall() && !tx.IsRewardConfig() &&
+		tx.Payload.ID == (MethodID{}) && len(tx.Payload.Args) == 0 && len(tx.Payload.Contract) == 0
+}
+
+// IsUpgrade checks whether tx replaces code of receiver, upgrade txs carry contract like deployments
+func (tx *Transaction) IsUpgrade() bool {
+	return tx.Receiver != EmptyAddress && tx.Payload != nil && len(tx.Payload.Contract) > 0
+}
+
+// IsMultiCall checks whether tx executes calls of its payload atomically
+func (tx *Transaction) IsMultiCall() bool {
+	return tx.Payload != nil && len(tx.Payload.Calls) > 0
+}
+
+// IsMultisigConfig checks whether tx sets keys and threshold of its multisig account
+func (tx *Transaction) IsMultisigConfig() bool {
+	return len(tx.MultisigKeys) > 0
+}
+
+// IsRewardConfig checks whether tx sets reward address of the validator sending it
+func (tx *Transaction) IsRewardConfig() bool {
+	return tx.RewardAddress != EmptyAddress
+}
+
+// IsScheduled checks whether tx queues its invocation for a later height or time
+func (tx *Transaction) IsScheduled() bool {
+	return tx.ScheduleHeight > 0 || tx.ScheduleTime > 0
+}
+
+// DeploymentAddress returns address of contract deployed by tx, it is derived from salt and code when salt is set
+func (tx *Transaction) DeploymentAddress() Address {
+	sender := tx.SenderAddress()
+	if len(tx.Salt) > 0 {
+		return NewSaltedDeploymentAddress(sender, tx.Salt, blake2b.Sum256(tx.Payload.Contract))
+	}
+	return NewDeploymentAddress(sender, tx.Sender.Nonce)
+}
+
 // DecodeTransaction returns Transaction from bytes representation
 func DecodeTransaction(raw []byte) (*Transaction, error) {
 	var tx Transaction
@@ -189,3 +189,81 @@ func TestTransaction_Hash(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_FeePayer(t *testing.T) {
+	senderKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	payerSeed := make([]byte, 32)
+	payerSeed[0] = 1
+	payerKey := ed25519.NewKeyFromSeed(payerSeed)
+
+	tx := &Transaction{
+		Version: 2,
+		Sender: &TxSender{
+			PublicKey: senderKey.Public().(ed25519.PublicKey),
+		},
+		Payload:  &TxPayload{Args: []byte{1}},
+		GasPrice: 1,
+		GasLimit: 2,
+		FeePayer: payerKey.Public().(ed25519.PublicKey),
+		FeeToken: NewDeploymentAddress(Address{}, 1),
+	}
+	if tx.Payer() != AddressFromPubKey(tx.FeePayer) {
+		t.Errorf("Transaction.Payer() = %v, want fee payer", tx.Payer())
+	}
+
+	sigHash := GetSigHash(tx)
+	tx.Signature = Sign(senderKey, sigHash.Bytes())
+	tx.FeePayerSignature = Sign(payerKey, sigHash.Bytes())
+	if GetSigHash(tx) != sigHash {
+		t.Errorf("GetSigHash() changed after signing")
+	}
+
+	encoded, _ := tx.Encode()
+	decoded, err := DecodeTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() || !cmp.Equal(decoded.FeePayerSignature, tx.FeePayerSignature) || decoded.FeeToken != tx.FeeToken {
+		t.Errorf("DecodeTransaction() = %v, want %v", decoded, tx)
+	}
+
+	tx.FeePayer = nil
+	tx.FeePayerSignature = nil
+	if tx.Payer() != AddressFromPubKey(tx.Sender.PublicKey) {
+		t.Errorf("Transaction.Payer() = %v, want sender", tx.Payer())
+	}
+	if _, err := DecodeTransaction(encoded[:len(encoded)-1]); err == nil {
+		t.Errorf("DecodeTransaction() of truncated tx expect error")
+	}
+}
+
+func TestTransaction_ChainID(t *testing.T) {
+	senderKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	tx := &Transaction{
+		Version: 3,
+		Sender: &TxSender{
+			PublicKey: senderKey.Public().(ed25519.PublicKey),
+		},
+		Payload:  &TxPayload{Args: []byte{1}},
+		GasPrice: 1,
+		GasLimit: 2,
+		ChainID:  "liquid",
+		Expiry:   10,
+	}
+	sigHash := GetSigHash(tx)
+	tx.Signature = Sign(senderKey, sigHash.Bytes())
+
+	encoded, _ := tx.Encode()
+	decoded, err := DecodeTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ChainID != tx.ChainID || decoded.Expiry != tx.Expiry || GetSigHash(decoded) != sigHash {
+		t.Errorf("DecodeTransaction() = %v, want %v", decoded, tx)
+	}
+
+	decoded.ChainID = "other"
+	if GetSigHash(decoded) == sigHash {
+		t.Errorf("GetSigHash() does not bind chain ID")
+	}
+}
@@ -21,7 +21,7 @@ func (db *MemoryDB) Get(key []byte) []byte {
 	return db.cache[hex.EncodeToString(key)]
 }
 
-// Put inserts an key-value pair to database
+// Put inserts an key-value pair to database, value is copied as callers like trie hasher reuse their buffer
 func (db *MemoryDB) Put(key []byte, value []byte) {
-	db.cache[hex.EncodeToString(key)] = value
+	db.cache[hex.EncodeToString(key)] = append([]byte{}, value...)
 }
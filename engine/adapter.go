@@ -25,22 +25,33 @@ func readAt(vm *vm.VM, ptr, size int) ([]byte, error) {
 func (engine *Engine) chainStorageSet(vm *vm.VM, args ...uint64) (uint64, error) {
 	keyPtr, keySize := int(args[0]), int(args[1])
 	valuePtr, valueSize := int(args[2]), int(args[3])
-	// Burn gas before actually execute
-	cost := engine.gasPolicy.GetCostForStorage(valueSize)
-	err := vm.BurnGas(cost)
+	key, err := readAt(vm, keyPtr, keySize)
 	if err != nil {
 		return 0, err
 	}
-	key, err := readAt(vm, keyPtr, keySize)
+	// Burn gas for writing to an existing slot before looking up the current value
+	baseCost, _ := engine.gasPolicy.GetCostForStorageWrite(valueSize, valueSize)
+	if err := vm.BurnGas(baseCost); err != nil {
+		return 0, err
+	}
+	current, err := engine.account.GetStorage(key)
 	if err != nil {
 		return 0, err
 	}
+	// Burn the rest before actually execute, price depends on whether slot is created, overwritten or deleted
+	cost, refund := engine.gasPolicy.GetCostForStorageWrite(len(current), valueSize)
+	if err := vm.BurnGas(cost - baseCost); err != nil {
+		return 0, err
+	}
 	value, err := readAt(vm, valuePtr, valueSize)
 	if err != nil {
 		return 0, err
 	}
-	err = engine.account.SetStorage(key, value)
-	return uint64(len(value)), err
+	if err := engine.account.SetStorage(key, value); err != nil {
+		return 0, err
+	}
+	engine.addRefund(refund)
+	return uint64(len(value)), nil
 }
 
 func (engine *Engine) chainStorageGet(vm *vm.VM, args ...uint64) (uint64, error) {
@@ -50,16 +61,31 @@ func (engine *Engine) chainStorageGet(vm *vm.VM, args ...uint64) (uint64, error)
 		return 0, err
 	}
 	valuePtr := int(uint32(args[2]))
+	// Burn gas for the lookup before actually execute
+	lookupCost := engine.gasPolicy.GetCostForStorageRead(0)
+	if err := vm.BurnGas(lookupCost); err != nil {
+		return 0, err
+	}
 	value, err := engine.account.GetStorage(key)
 	if err != nil {
 		return 0, err
 	}
+	// Burn gas for value size before copying it into memory
+	cost := engine.gasPolicy.GetCostForStorageRead(len(value)) - lookupCost
+	if err := vm.BurnGas(cost); err != nil {
+		return 0, err
+	}
 	byteSize, err := vm.MemWrite(value, valuePtr)
 	return uint64(byteSize), err
 }
 
 func (engine *Engine) chainStorageSizeGet(vm *vm.VM, args ...uint64) (uint64, error) {
 	keyPtr, keySize := int(args[0]), int(args[1])
+	// Burn gas for the lookup before actually execute
+	cost := engine.gasPolicy.GetCostForStorageRead(0)
+	if err := vm.BurnGas(cost); err != nil {
+		return 0, err
+	}
 	key, err := readAt(vm, keyPtr, keySize)
 	if err != nil {
 		return 0, err
@@ -145,15 +171,29 @@ func (engine *Engine) chainArgsHash(vm *vm.VM, args ...uint64) (uint64, error) {
 	}
 	memorySize := 4
 	argCnt := bufferSize / memorySize
-	var values [][]byte
+	ptrs := make([]int, argCnt)
+	ptrSizes := make([]int, argCnt)
+	totalSize := 0
 	for i := 0; i < argCnt; i++ {
 		ptrMem, err := readAt(vm, bufferPtr+i*memorySize, memorySize)
-		ptr := int(binary.LittleEndian.Uint32(ptrMem))
-		ptrSize, err := engine.ptrArgSizeGet(ptr)
 		if err != nil {
 			return 0, err
 		}
-		value, err := readAt(vm, ptr, ptrSize)
+		ptrs[i] = int(binary.LittleEndian.Uint32(ptrMem))
+		if ptrSizes[i], err = engine.ptrArgSizeGet(ptrs[i]); err != nil {
+			return 0, err
+		}
+		totalSize += ptrSizes[i]
+	}
+
+	// Burn gas before actually hashing
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForHash(totalSize)); err != nil {
+		return 0, err
+	}
+
+	var values [][]byte
+	for i := 0; i < argCnt; i++ {
+		value, err := readAt(vm, ptrs[i], ptrSizes[i])
 		if err != nil {
 			return 0, err
 		}
@@ -168,8 +208,13 @@ func (engine *Engine) chainArgsHash(vm *vm.VM, args ...uint64) (uint64, error) {
 	return 0, nil
 }
 
+// chainEd25519Verify checks signature by key of ed25519 address, other kinds of address fail verification
 func (engine *Engine) chainEd25519Verify(vm *vm.VM, args ...uint64) (uint64, error) {
 	addressPtr, hasherPtr, signaturePtr := int(args[0]), int(args[1]), int(args[2])
+	// Burn gas before actually verify
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForSignatureVerify()); err != nil {
+		return 0, err
+	}
 	addressBytes, err := readAt(vm, addressPtr, crypto.AddressLength)
 	if err != nil {
 		return 0, err
@@ -187,7 +232,42 @@ func (engine *Engine) chainEd25519Verify(vm *vm.VM, args ...uint64) (uint64, err
 		return 0, err
 	}
 	pubkey, err := address.PubKey()
-	if !ed25519.Verify(pubkey, hasher, signature) {
+	if err != nil || !ed25519.Verify(pubkey, hasher, signature) {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// chainSecp256k1Verify checks signature by compressed secp256k1 key given with its address,
+// address has no room for the key so key must hash to address
+func (engine *Engine) chainSecp256k1Verify(vm *vm.VM, args ...uint64) (uint64, error) {
+	addressPtr, keyPtr, hasherPtr, signaturePtr := int(args[0]), int(args[1]), int(args[2]), int(args[3])
+	// Burn gas before actually verify
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForSignatureVerify()); err != nil {
+		return 0, err
+	}
+	addressBytes, err := readAt(vm, addressPtr, crypto.AddressLength)
+	if err != nil {
+		return 0, err
+	}
+	address, err := crypto.AddressFromBytes(addressBytes)
+	if err != nil {
+		return 0, err
+	}
+	key, err := readAt(vm, keyPtr, crypto.Secp256k1PublicKeySize)
+	if err != nil {
+		return 0, err
+	}
+	hasher, err := readAt(vm, hasherPtr, 32)
+	if err != nil {
+		return 0, err
+	}
+	signature, err := readAt(vm, signaturePtr, 64)
+	if err != nil {
+		return 0, err
+	}
+	if crypto.AddressFromKey(crypto.KeyTypeSecp256k1, key) != address ||
+		!crypto.VerifyKeySignature(crypto.KeyTypeSecp256k1, key, hasher, signature) {
 		return 0, nil
 	}
 	return 1, nil
@@ -205,10 +285,22 @@ func (engine *Engine) handleInvokeAlias(foreignMethod *foreignMethod, vm *vm.VM,
 		return 0, errors.New("call depth limit reached")
 	}
 
+	// Burn gas for call setup before loading foreign contract
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForCall()); err != nil {
+		return 0, err
+	}
+
 	foreignAccount, err := engine.state.LoadAccount(foreignMethod.contractAddress)
 	if err != nil {
 		return 0, err
 	}
+	if foreignAccount == nil {
+		return 0, errors.New("contract not found")
+	}
+	// State is loaded from parent of the block being executed
+	if foreignAccount.Dormant(engine.state.GetBlock().Height + 1) {
+		return 0, errors.New("contract is dormant")
+	}
 	contract, err := foreignAccount.GetContract()
 	if err != nil {
 		return 0, err
@@ -270,6 +362,18 @@ func (engine *Engine) GetFunction(module, name string) vm.HostFunction {
 			return engine.chainStorageGet
 		case "chain_storage_size_get":
 			return engine.chainStorageSizeGet
+		case "chain_storage_iter":
+			return engine.chainStorageIter
+		case "chain_storage_iter_next":
+			return engine.chainStorageIterNext
+		case "chain_storage_iter_key_size":
+			return engine.chainStorageIterKeySize
+		case "chain_storage_iter_key":
+			return engine.chainStorageIterKey
+		case "chain_storage_iter_value_size":
+			return engine.chainStorageIterValueSize
+		case "chain_storage_iter_value":
+			return engine.chainStorageIterValue
 		case "chain_get_caller":
 			return engine.chainGetCaller
 		case "chain_get_creator":
@@ -290,8 +394,18 @@ func (engine *Engine) GetFunction(module, name string) vm.HostFunction {
 			return engine.chainArgsHash
 		case "chain_ed25519_verify":
 			return engine.chainEd25519Verify
+		case "chain_secp256k1_verify":
+			return engine.chainSecp256k1Verify
 		case "chain_get_contract_address":
 			return engine.chainGetContractAddress
+		case "chain_self_destruct":
+			return engine.chainSelfDestruct
+		case "chain_get_balance":
+			return engine.chainGetBalance
+		case "chain_send":
+			return engine.chainSend
+		case "chain_schedule":
+			return engine.chainSchedule
 		default:
 			contract, _ := engine.account.GetContract()
 			if event, err := contract.Header.GetEvent(name); err == nil {
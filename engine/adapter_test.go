@@ -5,9 +5,11 @@ import (
 	"io/ioutil"
 	"testing"
 
+	"github.com/QuoineFinancial/liquid-chain/crypto"
 	"github.com/QuoineFinancial/liquid-chain/gas"
 	"github.com/vertexdlt/vertexvm/vm"
 	vertex "github.com/vertexdlt/vertexvm/vm"
+	"golang.org/x/crypto/blake2b"
 )
 
 type testcase struct {
@@ -57,3 +59,53 @@ func TestAdapterGetFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestChainSignatureVerify(t *testing.T) {
+	privateKey := make([]byte, 32)
+	privateKey[0] = 1
+	key := crypto.Secp256k1PublicKey(privateKey)
+	address := crypto.AddressFromKey(crypto.KeyTypeSecp256k1, key)
+	hash := blake2b.Sum256([]byte("message"))
+	signature, err := crypto.SignSecp256k1(privateKey, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &Engine{gasPolicy: &gas.FreePolicy{}}
+	vm := getVM("exit")
+	vm.MemWrite(address[:], 0)
+	vm.MemWrite(key, 64)
+	vm.MemWrite(hash[:], 128)
+	vm.MemWrite(signature, 192)
+	if ret, err := engine.chainSecp256k1Verify(vm, 0, 64, 128, 192); err != nil || ret != 1 {
+		t.Errorf("Expect secp256k1 signature to be valid, got %d, %v", ret, err)
+	}
+	// Secp256k1 address has no ed25519 key
+	if ret, err := engine.chainEd25519Verify(vm, 0, 128, 192); err != nil || ret != 0 {
+		t.Errorf("Expect ed25519 verification of secp256k1 address to fail, got %d, %v", ret, err)
+	}
+
+	other := crypto.AddressFromKey(crypto.KeyTypeSecp256k1, append([]byte{}, hash[:]...))
+	vm.MemWrite(other[:], 0)
+	if ret, err := engine.chainSecp256k1Verify(vm, 0, 64, 128, 192); err != nil || ret != 0 {
+		t.Errorf("Expect key of other address to fail, got %d, %v", ret, err)
+	}
+}
+
+func TestChainStorageBurnsGasFirst(t *testing.T) {
+	// Engine has no account and iterator has no trie, any lookup before burning gas would panic
+	engine := &Engine{
+		gasPolicy: gas.NewAlphaPolicy(gas.DefaultSchedule()),
+		iterators: []*storageIterator{{}},
+	}
+	vm := getVM("exit")
+	if _, err := engine.chainStorageGet(vm, 0, 4, 64); err != vertex.ErrOutOfGas {
+		t.Errorf("Expect storage get to run out of gas, got %v", err)
+	}
+	if _, err := engine.chainStorageIterNext(vm, 0); err != vertex.ErrOutOfGas {
+		t.Errorf("Expect storage iterator step to run out of gas, got %v", err)
+	}
+	if _, err := engine.chainStorageSet(vm, 0, 4, 0, 4); err != vertex.ErrOutOfGas {
+		t.Errorf("Expect storage set to run out of gas, got %v", err)
+	}
+}
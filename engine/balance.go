@@ -0,0 +1,33 @@
+package engine
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/vertexdlt/vertexvm/vm"
+)
+
+// chainGetBalance returns native balance of executing contract
+func (engine *Engine) chainGetBalance(vm *vm.VM, args ...uint64) (uint64, error) {
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForStorageRead(0)); err != nil {
+		return 0, err
+	}
+	return engine.account.Balance, nil
+}
+
+// chainSend transfers native balance of executing contract to address read from pointer,
+// failing transfer aborts execution
+func (engine *Engine) chainSend(vm *vm.VM, args ...uint64) (uint64, error) {
+	addressPtr, amount := int(args[0]), args[1]
+	// Burn gas before actually execute
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForTransfer()); err != nil {
+		return 0, err
+	}
+	data, err := readAt(vm, addressPtr, crypto.AddressLength)
+	if err != nil {
+		return 0, err
+	}
+	address, err := crypto.AddressFromBytes(data)
+	if err != nil {
+		return 0, err
+	}
+	return 0, engine.state.Transfer(engine.account.GetAddress(), address, amount)
+}
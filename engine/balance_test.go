@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/db"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	vertex "github.com/vertexdlt/vertexvm/vm"
+)
+
+func TestNativeBalance(t *testing.T) {
+	address, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	receiver, _ := crypto.AddressFromString("LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT")
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.Block{Height: 1}); err != nil {
+		t.Fatal(err)
+	}
+	contract := loadContract("testdata/math-abi.json", "testdata/math.wasm")
+	contractBytes, _ := rlp.EncodeToBytes(contract)
+	account, _ := state.CreateAccount(address, address, contractBytes)
+	if err := state.AddBalance(address, 1000); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewEngine(state, account, address, &gas.AlphaPolicy{}, 100000)
+	vm, err := vertex.NewVM(contract.Code, engine.gasPolicy, engine.gas, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.MemWrite(receiver[:], 64)
+	initialGas := engine.GetGasUsed()
+
+	if _, err := engine.chainSend(vm, 64, 300); err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := engine.chainGetBalance(vm); balance != 700 {
+		t.Errorf("Expect contract balance %v, got %v", 700, balance)
+	}
+	if balance, _ := state.GetBalance(receiver); balance != 300 {
+		t.Errorf("Expect receiver balance %v, got %v", 300, balance)
+	}
+	if _, err := engine.chainSend(vm, 64, 701); err != storage.ErrInsufficientBalance {
+		t.Errorf("Expect error %v, got %v", storage.ErrInsufficientBalance, err)
+	}
+	wantGas := 2*gas.GasTransfer + gas.GasStorageRead
+	if gasUsed := engine.GetGasUsed() - initialGas; gasUsed != wantGas {
+		t.Errorf("Expect gas used %v, got %v", wantGas, gasUsed)
+	}
+
+	// Self destruct leaves balance to beneficiary
+	if _, err := engine.chainSelfDestruct(vm, 64); err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := state.GetBalance(receiver); balance != 1000 {
+		t.Errorf("Expect beneficiary balance %v, got %v", 1000, balance)
+	}
+}
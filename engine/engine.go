@@ -35,6 +35,9 @@ type Engine struct {
 	methodLookup  map[string]*foreignMethod
 	ptrArgSizeMap map[int]int
 	gas           *vertex.Gas
+	refund        uint64
+	iterators     []*storageIterator
+	destructed    []crypto.Address
 	parent        *Engine
 }
 
@@ -63,6 +66,16 @@ func (engine *Engine) GetGasUsed() uint64 {
 	return engine.gas.Used
 }
 
+// GetRefund return gas refund earned by freeing storage, before capping
+func (engine *Engine) GetRefund() uint64 {
+	return engine.refund
+}
+
+// GetDestructed returns contracts self destructed during execution, to be deleted if execution succeeds
+func (engine *Engine) GetDestructed() []crypto.Address {
+	return engine.destructed
+}
+
 // newChildEngine share with parent state except caller is contract itself
 func (engine *Engine) newChildEngine(account *storage.Account) *Engine {
 	return &Engine{
@@ -170,3 +183,11 @@ func (engine *Engine) pushEvent(event *crypto.Event) {
 		engine.events = append(engine.events, event)
 	}
 }
+
+func (engine *Engine) addRefund(refund uint64) {
+	if engine.parent != nil {
+		engine.parent.addRefund(refund)
+	} else {
+		engine.refund += refund
+	}
+}
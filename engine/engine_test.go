@@ -281,3 +281,48 @@ func TestDelegatedCall(t *testing.T) {
 		t.Errorf("Engine.Ignite() = %v, want %v", got, 0)
 	}
 }
+
+func TestEngineCallGas(t *testing.T) {
+	contractCreator, _ := crypto.AddressFromString("LDH4MEPOJX3EGN3BLBTLEYXVHYCN3AVA7IOE772F3XGI6VNZHAP6GX5R")
+	mathAddress, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	utilAddress, _ := crypto.AddressFromString("LCR57ROUHIQ2AV4D3E3D7ZBTR6YXMKZQWTI4KSHSWCUCRXBKNJKKBCNY")
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.Block{Height: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	mathContract := loadContract("testdata/math-abi.json", "testdata/math.wasm")
+	utilContract := loadContract("testdata/util-abi.json", "testdata/util.wasm")
+	mathBytes, _ := rlp.EncodeToBytes(mathContract)
+	utilBytes, _ := rlp.EncodeToBytes(utilContract)
+	if _, err := state.CreateAccount(contractCreator, mathAddress, mathBytes); err != nil {
+		t.Fatal(err)
+	}
+	utilAccount, _ := state.CreateAccount(contractCreator, utilAddress, utilBytes)
+
+	ignite := func(gasLimit uint64) (*Engine, error) {
+		execEngine := NewEngine(state, utilAccount, contractCreator, &gas.AlphaPolicy{}, gasLimit)
+		function, _ := utilContract.Header.GetFunction("init")
+		args, _ := abi.EncodeFromString(function.Parameters, []string{mathAddress.String()})
+		if _, err := execEngine.Ignite("init", args); err != nil {
+			return execEngine, err
+		}
+		function, _ = utilContract.Header.GetFunction("hypotenuse")
+		args, _ = abi.EncodeFromString(function.Parameters, []string{"3", "4"})
+		_, err := execEngine.Ignite("hypotenuse", args)
+		return execEngine, err
+	}
+
+	execEngine, err := ignite(10000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := execEngine.GetGasUsed()
+	if used < gas.GasCall {
+		t.Errorf("Engine.GetGasUsed() = %v, want at least %v", used, gas.GasCall)
+	}
+
+	if _, err := ignite(used - gas.GasCall); err == nil {
+		t.Error("Engine.Ignite() expected out of gas error")
+	}
+}
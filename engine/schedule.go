@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/vertexdlt/vertexvm/vm"
+)
+
+// chainSchedule queues call of method named at methodPtr on contract at addressPtr with encoded args,
+// executing contract is caller once block reaches due height, or due time when height is zero.
+// Gas limit of the call is prepaid by current execution with storing it, returns sequence of scheduled call.
+func (engine *Engine) chainSchedule(vm *vm.VM, args ...uint64) (uint64, error) {
+	addressPtr, methodPtr, methodSize := int(args[0]), int(args[1]), int(args[2])
+	argsPtr, argsSize := int(args[3]), int(args[4])
+	height, time, gasLimit := args[5], args[6], args[7]
+
+	block := engine.state.ExecutingBlock()
+	if block == nil {
+		return 0, errors.New("schedule requires block execution")
+	}
+	scheduleGasLimit := engine.state.ScheduleGasLimit()
+	if scheduleGasLimit == 0 {
+		return 0, errors.New("scheduling is disabled")
+	}
+	if (height == 0) == (time == 0) {
+		return 0, errors.New("schedule sets either height or time")
+	}
+	if height > 0 && height <= block.Height {
+		return 0, errors.New("schedule height must be after current block")
+	}
+	if time > 0 && time <= block.Time {
+		return 0, errors.New("schedule time must be after current block")
+	}
+	if gasLimit > scheduleGasLimit || gasLimit > math.MaxUint32 {
+		return 0, fmt.Errorf("schedule gas limit exceeds %d", scheduleGasLimit)
+	}
+
+	data, err := readAt(vm, addressPtr, crypto.AddressLength)
+	if err != nil {
+		return 0, err
+	}
+	receiver, err := crypto.AddressFromBytes(data)
+	if err != nil {
+		return 0, err
+	}
+	method, err := readAt(vm, methodPtr, methodSize)
+	if err != nil || methodSize == 0 {
+		return 0, errors.New("invalid scheduled method")
+	}
+	callArgs, err := readAt(vm, argsPtr, argsSize)
+	if err != nil {
+		return 0, err
+	}
+	call := &crypto.ScheduledCall{
+		Caller:   engine.account.GetAddress(),
+		Receiver: receiver,
+		ID:       crypto.GetMethodID(string(method[:methodSize-1])),
+		Args:     callArgs,
+		Height:   height,
+		Time:     time,
+		GasLimit: uint32(gasLimit),
+	}
+	encoded, err := call.Encode()
+	if err != nil {
+		return 0, err
+	}
+
+	// Burn gas before actually execute
+	if err := vm.BurnGas(gasLimit + engine.gasPolicy.GetCostForStorage(len(encoded))); err != nil {
+		return 0, err
+	}
+	if err := engine.state.Schedule(call); err != nil {
+		return 0, err
+	}
+	return call.Sequence, nil
+}
@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/db"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	vertex "github.com/vertexdlt/vertexvm/vm"
+)
+
+func TestChainSchedule(t *testing.T) {
+	address, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	receiver, _ := crypto.AddressFromString("LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT")
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.Block{Height: 5, Time: 100}); err != nil {
+		t.Fatal(err)
+	}
+	contract := loadContract("testdata/math-abi.json", "testdata/math.wasm")
+	contractBytes, _ := rlp.EncodeToBytes(contract)
+	account, _ := state.CreateAccount(address, address, contractBytes)
+
+	engine := NewEngine(state, account, address, &gas.AlphaPolicy{}, 100000)
+	vm, err := vertex.NewVM(contract.Code, engine.gasPolicy, engine.gas, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.MemWrite(receiver[:], 64)
+	vm.MemWrite([]byte("mean\x00"), 128)
+	vm.MemWrite([]byte{1, 2, 3}, 160)
+
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 7, 0, 500); err == nil {
+		t.Errorf("Expect schedule outside of block execution to fail")
+	}
+	state.SetExecutingBlock(&crypto.Block{Height: 6, Time: 150})
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 7, 0, 500); err == nil {
+		t.Errorf("Expect schedule while scheduling is disabled to fail")
+	}
+	state.SetScheduleGasLimit(1000)
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 6, 0, 500); err == nil {
+		t.Errorf("Expect schedule at current height to fail")
+	}
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 0, 150, 500); err == nil {
+		t.Errorf("Expect schedule at time of current block to fail")
+	}
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 7, 0, 1001); err == nil {
+		t.Errorf("Expect schedule above schedule gas limit to fail")
+	}
+	if _, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 7, 200, 500); err == nil {
+		t.Errorf("Expect schedule by both height and time to fail")
+	}
+	initialGas := engine.GetGasUsed()
+	if sequence, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 0, 200, 500); err != nil || sequence != 0 {
+		t.Fatalf("Expect first sequence, got %d, %v", sequence, err)
+	}
+	if gasUsed := engine.GetGasUsed() - initialGas; gasUsed <= 500 {
+		t.Errorf("Expect gas limit and storage paid, got %v", gasUsed)
+	}
+	if sequence, err := engine.chainSchedule(vm, 64, 128, 5, 160, 3, 7, 0, 500); err != nil || sequence != 1 {
+		t.Fatalf("Expect second sequence, got %d, %v", sequence, err)
+	}
+
+	// Calls due by height run before calls due by time
+	calls, err := state.DueCalls(7, 200, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(calls) != 2 || calls[0].Sequence != 1 || calls[1].Sequence != 0 {
+		t.Fatalf("Expect calls by height then time, got %v", calls)
+	}
+	want := crypto.ScheduledCall{
+		Sequence: 1,
+		Caller:   address,
+		Receiver: receiver,
+		ID:       crypto.GetMethodID("mean"),
+		Args:     []byte{1, 2, 3},
+		Height:   7,
+		GasLimit: 500,
+	}
+	if calls[0].Hash() != want.Hash() {
+		t.Errorf("Expect scheduled call %v, got %v", want, calls[0])
+	}
+	if calls, _ := state.DueCalls(7, 200, 600); len(calls) != 1 {
+		t.Errorf("Expect calls limited by gas, got %v", calls)
+	}
+	if calls, _ := state.DueCalls(6, 199, 1000); len(calls) != 0 {
+		t.Errorf("Expect no due calls, got %v", calls)
+	}
+}
@@ -0,0 +1,90 @@
+package engine
+
+import (
+	"errors"
+
+	"github.com/QuoineFinancial/liquid-chain/abi"
+	"github.com/QuoineFinancial/liquid-chain/constant"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/vertexdlt/vertexvm/vm"
+)
+
+// SelfDestructCallbackName is function of beneficiary contract called with address of self destructed contract
+const SelfDestructCallbackName = "on_self_destruct"
+
+// chainSelfDestruct marks executing contract for deletion once transaction succeeds, the contract keeps
+// working until then. Beneficiary address is read from pointer and receives native balance of the contract,
+// empty address skips the callback and balance is destroyed with the contract.
+func (engine *Engine) chainSelfDestruct(vm *vm.VM, args ...uint64) (uint64, error) {
+	data, err := readAt(vm, int(args[0]), crypto.AddressLength)
+	if err != nil {
+		return 0, err
+	}
+	address := engine.account.GetAddress()
+	if engine.destruct(address) {
+		engine.addRefund(engine.gasPolicy.GetRefundForSelfDestruct())
+	}
+
+	var beneficiary crypto.Address
+	copy(beneficiary[:], data)
+	if beneficiary == crypto.EmptyAddress || beneficiary == address {
+		return 0, nil
+	}
+	if err := engine.state.Transfer(address, beneficiary, engine.account.Balance); err != nil {
+		return 0, err
+	}
+	return 0, engine.callBeneficiary(vm, beneficiary)
+}
+
+// callBeneficiary invokes on_self_destruct of beneficiary, beneficiaries not declaring it are skipped
+func (engine *Engine) callBeneficiary(vm *vm.VM, beneficiary crypto.Address) error {
+	if engine.callDepth+1 > constant.MaxEngineCallDepth {
+		return errors.New("call depth limit reached")
+	}
+
+	// Burn gas for call setup before loading beneficiary
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForCall()); err != nil {
+		return err
+	}
+
+	account, err := engine.state.LoadAccount(beneficiary)
+	if err != nil {
+		return err
+	}
+	// State is loaded from parent of the block being executed
+	if account == nil || !account.IsContract() || account.Dormant(engine.state.GetBlock().Height+1) {
+		return nil
+	}
+	contract, err := account.GetContract()
+	if err != nil {
+		return err
+	}
+	function, err := contract.Header.GetFunction(SelfDestructCallbackName)
+	if err != nil {
+		return nil
+	}
+	address := engine.account.GetAddress()
+	methodArgs, err := abi.EncodeFromBytes(function.Parameters, [][]byte{address[:]})
+	if err != nil {
+		return err
+	}
+
+	childEngine := engine.newChildEngine(account)
+	childEngine.setStats(engine.callDepth+1, engine.memAggr+vm.MemSize())
+	_, err = childEngine.Ignite(SelfDestructCallbackName, methodArgs)
+	return err
+}
+
+// destruct records address for deletion on root engine, returns false if it is already recorded
+func (engine *Engine) destruct(address crypto.Address) bool {
+	if engine.parent != nil {
+		return engine.parent.destruct(address)
+	}
+	for _, destructed := range engine.destructed {
+		if destructed == address {
+			return false
+		}
+	}
+	engine.destructed = append(engine.destructed, address)
+	return true
+}
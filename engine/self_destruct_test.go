@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/db"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	vertex "github.com/vertexdlt/vertexvm/vm"
+)
+
+func TestSelfDestruct(t *testing.T) {
+	address, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	beneficiary, _ := crypto.AddressFromString("LA5WUJ54Z23KILLCUOUNAKTPBVZWKMQVO4O6EQ5GHLAERIMLLHNCTXXT")
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.Block{Height: 1}); err != nil {
+		t.Fatal(err)
+	}
+	contract := loadContract("testdata/math-abi.json", "testdata/math.wasm")
+	contractBytes, _ := rlp.EncodeToBytes(contract)
+	account, _ := state.CreateAccount(address, address, contractBytes)
+	calleeAddress := crypto.NewDeploymentAddress(address, 0)
+	callee, _ := state.CreateAccount(address, calleeAddress, contractBytes)
+
+	engine := NewEngine(state, account, address, &gas.AlphaPolicy{}, 100000)
+	vm, err := vertex.NewVM(contract.Code, engine.gasPolicy, engine.gas, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.MemWrite(beneficiary[:], 64)
+	initialGas := engine.GetGasUsed()
+
+	// Beneficiary without code is not called back, destructing again earns no refund
+	for i := 0; i < 2; i++ {
+		if _, err := engine.chainSelfDestruct(vm, 64); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gasUsed := engine.GetGasUsed() - initialGas; gasUsed != 2*gas.GasCall {
+		t.Errorf("Expect gas used %v, got %v", 2*gas.GasCall, gasUsed)
+	}
+	if engine.GetRefund() != gas.GasSelfDestructRefund {
+		t.Errorf("Expect refund %v, got %v", gas.GasSelfDestructRefund, engine.GetRefund())
+	}
+
+	// Nested contract is recorded on root engine, empty beneficiary skips callback
+	childEngine := engine.newChildEngine(callee)
+	if _, err := childEngine.chainSelfDestruct(vm, 0); err != nil {
+		t.Fatal(err)
+	}
+	destructed := engine.GetDestructed()
+	if len(destructed) != 2 || destructed[0] != address || destructed[1] != calleeAddress {
+		t.Errorf("Expect destructed %v, got %v", []crypto.Address{address, calleeAddress}, destructed)
+	}
+	if len(childEngine.GetDestructed()) != 0 {
+		t.Errorf("Expect child engine to record nothing, got %v", childEngine.GetDestructed())
+	}
+	if engine.GetRefund() != 2*gas.GasSelfDestructRefund {
+		t.Errorf("Expect refund %v, got %v", 2*gas.GasSelfDestructRefund, engine.GetRefund())
+	}
+
+	// Accounts stay until caller deletes them
+	if loaded, _ := state.LoadAccount(address); loaded == nil {
+		t.Errorf("Expect account %v to be kept during execution", address)
+	}
+}
@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/QuoineFinancial/liquid-chain/trie"
+	"github.com/vertexdlt/vertexvm/vm"
+)
+
+// maxStorageIterators limits open iterators of a contract invocation
+const maxStorageIterators = 16
+
+var errInvalidIterator = errors.New("invalid storage iterator")
+
+// storageIterator walks keys of contract storage under a prefix
+type storageIterator struct {
+	prefix []byte
+	it     *trie.Iterator
+	key    []byte
+	value  []byte
+}
+
+func (engine *Engine) getStorageIterator(handle uint64) (*storageIterator, error) {
+	if handle >= uint64(len(engine.iterators)) {
+		return nil, errInvalidIterator
+	}
+	return engine.iterators[handle], nil
+}
+
+// chainStorageIter opens iterator over keys with prefix starting from start key, returns its handle
+func (engine *Engine) chainStorageIter(vm *vm.VM, args ...uint64) (uint64, error) {
+	prefixPtr, prefixSize := int(args[0]), int(args[1])
+	startPtr, startSize := int(args[2]), int(args[3])
+	if len(engine.iterators) >= maxStorageIterators {
+		return 0, errors.New("storage iterator limit reached")
+	}
+	// Burn gas for seek before actually execute
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForStorageRead(0)); err != nil {
+		return 0, err
+	}
+	prefix, err := readAt(vm, prefixPtr, prefixSize)
+	if err != nil {
+		return 0, err
+	}
+	start, err := readAt(vm, startPtr, startSize)
+	if err != nil {
+		return 0, err
+	}
+	if bytes.Compare(start, prefix) < 0 {
+		start = prefix
+	}
+	engine.iterators = append(engine.iterators, &storageIterator{
+		prefix: prefix,
+		it:     engine.account.StorageIterator(start),
+	})
+	return uint64(len(engine.iterators) - 1), nil
+}
+
+// chainStorageIterNext moves iterator to next key under its prefix, returns 0 once exhausted
+func (engine *Engine) chainStorageIterNext(vm *vm.VM, args ...uint64) (uint64, error) {
+	iterator, err := engine.getStorageIterator(args[0])
+	if err != nil {
+		return 0, err
+	}
+	// Every step is paid as a read of the entry, lookup is burnt before actually execute
+	lookupCost := engine.gasPolicy.GetCostForStorageRead(0)
+	if err := vm.BurnGas(lookupCost); err != nil {
+		return 0, err
+	}
+	if iterator.it.Next() && bytes.HasPrefix(iterator.it.Key, iterator.prefix) {
+		iterator.key = append([]byte{}, iterator.it.Key...)
+		iterator.value = append([]byte{}, iterator.it.Value...)
+	} else {
+		if iterator.it.Err != nil {
+			return 0, iterator.it.Err
+		}
+		iterator.key, iterator.value = nil, nil
+	}
+	if err := vm.BurnGas(engine.gasPolicy.GetCostForStorageRead(len(iterator.key)+len(iterator.value)) - lookupCost); err != nil {
+		return 0, err
+	}
+	if iterator.key == nil {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+func (engine *Engine) chainStorageIterKeySize(vm *vm.VM, args ...uint64) (uint64, error) {
+	iterator, err := engine.getStorageIterator(args[0])
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(iterator.key)), nil
+}
+
+func (engine *Engine) chainStorageIterKey(vm *vm.VM, args ...uint64) (uint64, error) {
+	iterator, err := engine.getStorageIterator(args[0])
+	if err != nil {
+		return 0, err
+	}
+	size, err := vm.MemWrite(iterator.key, int(uint32(args[1])))
+	return uint64(size), err
+}
+
+func (engine *Engine) chainStorageIterValueSize(vm *vm.VM, args ...uint64) (uint64, error) {
+	iterator, err := engine.getStorageIterator(args[0])
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(iterator.value)), nil
+}
+
+func (engine *Engine) chainStorageIterValue(vm *vm.VM, args ...uint64) (uint64, error) {
+	iterator, err := engine.getStorageIterator(args[0])
+	if err != nil {
+		return 0, err
+	}
+	size, err := vm.MemWrite(iterator.value, int(uint32(args[1])))
+	return uint64(size), err
+}
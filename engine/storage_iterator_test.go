@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/db"
+	"github.com/QuoineFinancial/liquid-chain/gas"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+	vertex "github.com/vertexdlt/vertexvm/vm"
+)
+
+func TestStorageIterator(t *testing.T) {
+	address, _ := crypto.AddressFromString("LADSUJQLIKT4WBBLGLJ6Q36DEBJ6KFBQIIABD6B3ZWF7NIE4RIZURI53")
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.Block{Height: 1}); err != nil {
+		t.Fatal(err)
+	}
+	contract := loadContract("testdata/math-abi.json", "testdata/math.wasm")
+	contractBytes, _ := rlp.EncodeToBytes(contract)
+	account, _ := state.CreateAccount(address, address, contractBytes)
+	for _, key := range []string{"a1", "b1", "b2", "b3", "c1"} {
+		if err := account.SetStorage([]byte(key), []byte("value-"+key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	engine := NewEngine(state, account, address, &gas.AlphaPolicy{}, 100000)
+	vm, err := vertex.NewVM(contract.Code, engine.gasPolicy, engine.gas, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm.MemWrite([]byte("b"), 0)
+	vm.MemWrite([]byte("b2"), 8)
+	initialGas := engine.GetGasUsed()
+
+	handle, err := engine.chainStorageIter(vm, 0, 1, 8, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys, values []string
+	for {
+		ok, err := engine.chainStorageIterNext(vm, handle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok == 0 {
+			break
+		}
+		keySize, _ := engine.chainStorageIterKeySize(vm, handle)
+		valueSize, _ := engine.chainStorageIterValueSize(vm, handle)
+		engine.chainStorageIterKey(vm, handle, 16)
+		engine.chainStorageIterValue(vm, handle, 32)
+		key, _ := readAt(vm, 16, int(keySize))
+		value, _ := readAt(vm, 32, int(valueSize))
+		keys = append(keys, string(key))
+		values = append(values, string(value))
+	}
+
+	if len(keys) != 2 || keys[0] != "b2" || keys[1] != "b3" {
+		t.Errorf("Expect keys %v, got %v", []string{"b2", "b3"}, keys)
+	}
+	if len(values) != 2 || values[0] != "value-b2" || values[1] != "value-b3" {
+		t.Errorf("Expect values %v, got %v", []string{"value-b2", "value-b3"}, values)
+	}
+	// Seek and three steps, each paid as a storage read
+	wantGas := 4*gas.GasStorageRead + 2*uint64(len("b2")+len("value-b2"))
+	if gasUsed := engine.GetGasUsed() - initialGas; gasUsed != wantGas {
+		t.Errorf("Expect gas used %v, got %v", wantGas, gasUsed)
+	}
+
+	if _, err := engine.chainStorageIterNext(vm, handle+1); err != errInvalidIterator {
+		t.Errorf("Expect error %v, got %v", errInvalidIterator, err)
+	}
+}
@@ -18,6 +18,18 @@ const (
 	GasMemoryPage uint64 = 1024
 )
 
+// Cost for host functions
+const (
+	GasStorageRead        uint64 = 50
+	GasStorageSlot        uint64 = 100
+	GasStorageSlotRefund  uint64 = 50
+	GasSelfDestructRefund uint64 = 5000
+	GasHash               uint64 = 30
+	GasSignatureVerify    uint64 = 3000
+	GasCall               uint64 = 700
+	GasTransfer           uint64 = 1000
+)
+
 func newGasTable() gasTable {
 	return gasTable{
 		opcode.Block:             GasFrame + GasBlock,
@@ -195,32 +207,120 @@ func newGasTable() gasTable {
 
 var gasAlphaTable = newGasTable()
 
-// AlphaPolicy is a simple policy for first version
+var defaultSchedule = DefaultSchedule()
+
+// AlphaPolicy is a simple policy for first version, zero value uses the default schedule
 type AlphaPolicy struct {
 	Policy
+	table    *gasTable
+	schedule *Schedule
+}
+
+// NewAlphaPolicy returns policy with costs taken from schedule
+func NewAlphaPolicy(schedule *Schedule) *AlphaPolicy {
+	table := newGasTable()
+	for op, cost := range schedule.Ops {
+		table[op] = cost
+	}
+	return &AlphaPolicy{
+		table:    &table,
+		schedule: schedule,
+	}
+}
+
+func (p *AlphaPolicy) getSchedule() *Schedule {
+	if p.schedule == nil {
+		return defaultSchedule
+	}
+	return p.schedule
 }
 
 // GetCostForOp get cost from table
 func (p *AlphaPolicy) GetCostForOp(op opcode.Opcode) uint64 {
-	return gasAlphaTable[op]
+	if p.table == nil {
+		return gasAlphaTable[op]
+	}
+	return p.table[op]
 }
 
 // GetCostForStorage size of data
 func (p *AlphaPolicy) GetCostForStorage(size int) uint64 {
-	return uint64(size)
+	return p.getSchedule().StorageByte * uint64(size)
+}
+
+// GetCostForStorageWrite prices a write replacing value of oldSize with one of newSize,
+// creating a slot pays for the slot, deleting or shrinking earns refund
+func (p *AlphaPolicy) GetCostForStorageWrite(oldSize, newSize int) (uint64, uint64) {
+	schedule := p.getSchedule()
+	cost := p.GetCostForStorage(newSize)
+	if oldSize == 0 && newSize > 0 {
+		cost += schedule.StorageSlot
+	}
+	refund := uint64(0)
+	if newSize < oldSize {
+		refund = schedule.StorageByteRefund * uint64(oldSize-newSize)
+		if newSize == 0 {
+			refund += schedule.StorageSlotRefund
+		}
+	}
+	return cost, refund
+}
+
+// GetRefund caps refund earned by a transaction to a share of its gas used
+func (p *AlphaPolicy) GetRefund(gasUsed uint64, refund uint64) uint64 {
+	quotient := p.getSchedule().MaxRefundQuotient
+	if quotient == 0 {
+		return 0
+	}
+	if max := gasUsed / quotient; refund > max {
+		return max
+	}
+	return refund
+}
+
+// GetRefundForSelfDestruct returns refund for deleting a contract account
+func (p *AlphaPolicy) GetRefundForSelfDestruct() uint64 {
+	return p.getSchedule().SelfDestructRefund
+}
+
+// GetCostForStorageRead lookup and size of data read
+func (p *AlphaPolicy) GetCostForStorageRead(size int) uint64 {
+	schedule := p.getSchedule()
+	return schedule.StorageRead + schedule.StorageReadByte*uint64(size)
 }
 
 // GetCostForContract creation
 func (p *AlphaPolicy) GetCostForContract(size int) uint64 {
-	return uint64(size)
+	return p.getSchedule().ContractByte * uint64(size)
 }
 
 // GetCostForEvent emission
 func (p *AlphaPolicy) GetCostForEvent(size int) uint64 {
-	return uint64(size)
+	return p.getSchedule().EventByte * uint64(size)
 }
 
 // GetCostForMalloc returns cost for new memory allocation
 func (p *AlphaPolicy) GetCostForMalloc(pages int) uint64 {
-	return GasMemoryPage * uint64(pages)
+	return p.getSchedule().MemoryPage * uint64(pages)
+}
+
+// GetCostForHash of data size
+func (p *AlphaPolicy) GetCostForHash(size int) uint64 {
+	schedule := p.getSchedule()
+	return schedule.Hash + schedule.HashByte*uint64(size)
+}
+
+// GetCostForSignatureVerify returns cost for a signature verification
+func (p *AlphaPolicy) GetCostForSignatureVerify() uint64 {
+	return p.getSchedule().SignatureVerify
+}
+
+// GetCostForCall returns overhead for a cross-contract call
+func (p *AlphaPolicy) GetCostForCall() uint64 {
+	return p.getSchedule().Call
+}
+
+// GetCostForTransfer returns cost for moving native balance
+func (p *AlphaPolicy) GetCostForTransfer() uint64 {
+	return p.getSchedule().Transfer
 }
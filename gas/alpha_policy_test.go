@@ -28,4 +28,87 @@ func TestAlphaPolicy(t *testing.T) {
 	if cost != GasMemoryPage {
 		t.Errorf("Expect cost %v, got %v", GasMemoryPage, cost)
 	}
+	cost = policy.GetCostForStorageRead(100)
+	if cost != GasStorageRead+100 {
+		t.Errorf("Expect cost %v, got %v", GasStorageRead+100, cost)
+	}
+	cost = policy.GetCostForHash(100)
+	if cost != GasHash+100 {
+		t.Errorf("Expect cost %v, got %v", GasHash+100, cost)
+	}
+	cost = policy.GetCostForSignatureVerify()
+	if cost != GasSignatureVerify {
+		t.Errorf("Expect cost %v, got %v", GasSignatureVerify, cost)
+	}
+	cost = policy.GetCostForCall()
+	if cost != GasCall {
+		t.Errorf("Expect cost %v, got %v", GasCall, cost)
+	}
+}
+
+func TestAlphaPolicyWithSchedule(t *testing.T) {
+	schedule := DefaultSchedule()
+	schedule.Ops = map[opcode.Opcode]uint64{opcode.Select: 9}
+	schedule.StorageByte = 2
+	schedule.MemoryPage = 10
+	policy := NewAlphaPolicy(schedule)
+
+	cost := policy.GetCostForOp(opcode.Select)
+	if cost != 9 {
+		t.Errorf("Expect cost %v, got %v", 9, cost)
+	}
+	cost = policy.GetCostForOp(opcode.I32Add)
+	if cost != gasAlphaTable[opcode.I32Add] {
+		t.Errorf("Expect cost %v, got %v", gasAlphaTable[opcode.I32Add], cost)
+	}
+	cost = policy.GetCostForStorage(100)
+	if cost != 200 {
+		t.Errorf("Expect cost %v, got %v", 200, cost)
+	}
+	cost = policy.GetCostForMalloc(2)
+	if cost != 20 {
+		t.Errorf("Expect cost %v, got %v", 20, cost)
+	}
+}
+
+func TestAlphaPolicyStorageWrite(t *testing.T) {
+	policy := AlphaPolicy{}
+	tests := []struct {
+		name       string
+		oldSize    int
+		newSize    int
+		wantCost   uint64
+		wantRefund uint64
+	}{
+		{"new slot", 0, 10, GasStorageSlot + 10, 0},
+		{"overwrite", 10, 10, 10, 0},
+		{"grow", 10, 20, 20, 0},
+		{"shrink", 10, 4, 4, 6},
+		{"delete", 10, 0, 0, GasStorageSlotRefund + 10},
+		{"delete missing", 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cost, refund := policy.GetCostForStorageWrite(tt.oldSize, tt.newSize)
+			if cost != tt.wantCost || refund != tt.wantRefund {
+				t.Errorf("Expect cost %v refund %v, got %v %v", tt.wantCost, tt.wantRefund, cost, refund)
+			}
+		})
+	}
+}
+
+func TestAlphaPolicyRefund(t *testing.T) {
+	policy := AlphaPolicy{}
+	if refund := policy.GetRefund(1000, 100); refund != 100 {
+		t.Errorf("Expect refund %v, got %v", 100, refund)
+	}
+	if refund := policy.GetRefund(1000, 800); refund != 500 {
+		t.Errorf("Expect refund %v, got %v", 500, refund)
+	}
+
+	schedule := DefaultSchedule()
+	schedule.MaxRefundQuotient = 0
+	if refund := NewAlphaPolicy(schedule).GetRefund(1000, 100); refund != 0 {
+		t.Errorf("Expect refund %v, got %v", 0, refund)
+	}
 }
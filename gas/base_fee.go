@@ -0,0 +1,70 @@
+package gas
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// BurnAddress receives burnt base fee, it is derived from a hash so nobody holds its key
+var BurnAddress = crypto.NewDeploymentAddress(crypto.EmptyAddress, 0)
+
+// BaseFeeConfig controls how base fee follows block gas utilization
+type BaseFeeConfig struct {
+	Initial           uint32 `json:"initial"`
+	Minimum           uint32 `json:"minimum"`
+	TargetGas         uint64 `json:"targetGas"`
+	ChangeDenominator uint64 `json:"changeDenominator"`
+}
+
+// DefaultBaseFeeConfig returns base fee settings of the first version
+func DefaultBaseFeeConfig() *BaseFeeConfig {
+	return &BaseFeeConfig{
+		Initial:           18,
+		Minimum:           18,
+		TargetGas:         10000000,
+		ChangeDenominator: 8,
+	}
+}
+
+// mulDiv returns a * b / c without overflow, given b <= c
+func mulDiv(a, b, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	quotient, _ := bits.Div64(hi, lo, c)
+	return quotient
+}
+
+// Next returns base fee of the block following a parent with given base fee and gas used
+func (config *BaseFeeConfig) Next(parentBaseFee uint32, parentGasUsed uint64) uint32 {
+	if parentBaseFee == 0 {
+		return config.Initial
+	}
+	if config.TargetGas == 0 || config.ChangeDenominator == 0 || parentGasUsed == config.TargetGas {
+		return parentBaseFee
+	}
+
+	next := uint64(parentBaseFee)
+	if parentGasUsed > config.TargetGas {
+		// Increase is capped at 1/ChangeDenominator per block
+		excess := parentGasUsed - config.TargetGas
+		if excess > config.TargetGas {
+			excess = config.TargetGas
+		}
+		delta := mulDiv(next, excess, config.TargetGas) / config.ChangeDenominator
+		if delta == 0 {
+			delta = 1
+		}
+		next += delta
+		if next > math.MaxUint32 {
+			next = math.MaxUint32
+		}
+	} else {
+		next -= mulDiv(next, config.TargetGas-parentGasUsed, config.TargetGas) / config.ChangeDenominator
+	}
+
+	if next < uint64(config.Minimum) {
+		return config.Minimum
+	}
+	return uint32(next)
+}
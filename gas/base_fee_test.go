@@ -0,0 +1,33 @@
+package gas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBaseFeeConfigNext(t *testing.T) {
+	config := &BaseFeeConfig{Initial: 100, Minimum: 50, TargetGas: 1000, ChangeDenominator: 8}
+	tests := []struct {
+		name          string
+		parentBaseFee uint32
+		parentGasUsed uint64
+		want          uint32
+	}{
+		{"genesis parent", 0, 0, 100},
+		{"at target", 100, 1000, 100},
+		{"full block", 200, 2000, 225},
+		{"above limit is capped", 200, 100000, 225},
+		{"small increase", 100, 1001, 101},
+		{"empty block", 200, 0, 175},
+		{"half block", 200, 500, 188},
+		{"minimum", 51, 0, 50},
+		{"maximum", math.MaxUint32, 2000, math.MaxUint32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.Next(tt.parentBaseFee, tt.parentGasUsed); got != tt.want {
+				t.Errorf("BaseFeeConfig.Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package gas
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// FeeCollectorAddress keeps collected fees until they are paid out at the end of block
+var FeeCollectorAddress = crypto.NewDeploymentAddress(crypto.EmptyAddress, 1)
+
+// FeeShare is a validator receiving collected fees proportional to its voting power
+type FeeShare struct {
+	Address crypto.Address
+	Power   uint64
+}
+
+// Payout is an amount paid to an address
+type Payout struct {
+	Address crypto.Address
+	Amount  uint64
+}
+
+// Distribution describes how collected fees of a block are paid out,
+// Fallback takes share of validators when none of them has a reward address
+type Distribution struct {
+	Proposer        *crypto.Address
+	ProposerPercent uint64
+	Validators      []*FeeShare
+	Fallback        *crypto.Address
+}
+
+// Payouts splits total between proposer and validators, rounding remainder stays with collector
+func (distribution *Distribution) Payouts(total uint64) []*Payout {
+	if distribution == nil || total == 0 {
+		return nil
+	}
+
+	var payouts []*Payout
+	remaining := total
+	if distribution.Proposer != nil && distribution.ProposerPercent > 0 {
+		percent := distribution.ProposerPercent
+		if percent > 100 {
+			percent = 100
+		}
+		amount := mulDiv(total, percent, 100)
+		if amount > 0 {
+			payouts = append(payouts, &Payout{*distribution.Proposer, amount})
+		}
+		remaining -= amount
+	}
+
+	totalPower := uint64(0)
+	for _, validator := range distribution.Validators {
+		totalPower += validator.Power
+	}
+	if totalPower == 0 {
+		if distribution.Fallback != nil && remaining > 0 {
+			payouts = append(payouts, &Payout{*distribution.Fallback, remaining})
+		}
+		return payouts
+	}
+	for _, validator := range distribution.Validators {
+		amount := mulDiv(remaining, validator.Power, totalPower)
+		if amount > 0 {
+			payouts = append(payouts, &Payout{validator.Address, amount})
+		}
+	}
+	return payouts
+}
@@ -0,0 +1,67 @@
+package gas
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribution_Payouts(t *testing.T) {
+	proposer := crypto.NewDeploymentAddress(crypto.EmptyAddress, 10)
+	validatorA := crypto.NewDeploymentAddress(crypto.EmptyAddress, 11)
+	validatorB := crypto.NewDeploymentAddress(crypto.EmptyAddress, 12)
+	validators := []*FeeShare{{validatorA, 1}, {validatorB, 2}}
+
+	tests := []struct {
+		name         string
+		distribution *Distribution
+		total        uint64
+		want         []*Payout
+	}{{
+		name:         "Nil distribution",
+		distribution: nil,
+		total:        100,
+		want:         nil,
+	}, {
+		name:         "Nothing collected",
+		distribution: &Distribution{Proposer: &proposer, ProposerPercent: 20, Validators: validators},
+		total:        0,
+		want:         nil,
+	}, {
+		name:         "Proposer and validators",
+		distribution: &Distribution{Proposer: &proposer, ProposerPercent: 20, Validators: validators},
+		total:        1000,
+		want:         []*Payout{{proposer, 200}, {validatorA, 266}, {validatorB, 533}},
+	}, {
+		name:         "Unknown proposer leaves all to validators",
+		distribution: &Distribution{ProposerPercent: 20, Validators: validators},
+		total:        900,
+		want:         []*Payout{{validatorA, 300}, {validatorB, 600}},
+	}, {
+		name:         "No validators keeps remaining in collector",
+		distribution: &Distribution{Proposer: &proposer, ProposerPercent: 50},
+		total:        100,
+		want:         []*Payout{{proposer, 50}},
+	}, {
+		name:         "No validators pays remaining to fallback",
+		distribution: &Distribution{Proposer: &proposer, ProposerPercent: 50, Fallback: &validatorA},
+		total:        100,
+		want:         []*Payout{{proposer, 50}, {validatorA, 50}},
+	}, {
+		name:         "Fallback takes all without reward addresses",
+		distribution: &Distribution{ProposerPercent: 20, Fallback: &validatorA},
+		total:        100,
+		want:         []*Payout{{validatorA, 100}},
+	}, {
+		name:         "Fallback is unused with validators",
+		distribution: &Distribution{ProposerPercent: 20, Validators: validators, Fallback: &proposer},
+		total:        900,
+		want:         []*Payout{{validatorA, 300}, {validatorB, 600}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.distribution.Payouts(tt.total))
+		})
+	}
+}
@@ -11,12 +11,12 @@ type DummyStation struct {
 }
 
 // Sufficient gas of an address is enough for burn
-func (station *DummyStation) Sufficient(addr crypto.Address, gas uint64) bool {
+func (station *DummyStation) Sufficient(addr crypto.Address, gas uint64, feeToken crypto.Address) bool {
 	return gas != 0
 }
 
 // Burn gas, do nothing
-func (station *DummyStation) Burn(addr crypto.Address, gas uint64) []*crypto.Event {
+func (station *DummyStation) Burn(addr crypto.Address, gasUsed uint64, price uint32, feeToken crypto.Address) []*crypto.Event {
 	return nil
 }
 
@@ -35,6 +35,22 @@ func (station *DummyStation) CheckGasPrice(price uint32) bool {
 	return false
 }
 
+// SetSchedule does nothing
+func (station *DummyStation) SetSchedule(schedule *Schedule) {}
+
+// SetBaseFee does nothing
+func (station *DummyStation) SetBaseFee(baseFee uint32) {}
+
+// Distribute does nothing
+func (station *DummyStation) Distribute(distribution *Distribution) []*crypto.Event {
+	return nil
+}
+
+// ID of station
+func (station *DummyStation) ID() StationID {
+	return DummyStationID
+}
+
 // NewDummyStation constructor
 func NewDummyStation(app App) Station {
 	return &DummyStation{
@@ -16,7 +16,7 @@ func TestDummyStation_Sufficient(t *testing.T) {
 		toAddr := crypto.AddressFromPubKey(pub)
 		want := false
 
-		if got := station.Sufficient(toAddr, uint64(0)); got != want {
+		if got := station.Sufficient(toAddr, uint64(0), crypto.EmptyAddress); got != want {
 			t.Errorf("DummyStation.Sufficient() = %v, want %v", got, want)
 		}
 	})
@@ -27,7 +27,7 @@ func TestDummyStation_Sufficient(t *testing.T) {
 		toAddr := crypto.AddressFromPubKey(pub)
 		want := true
 
-		if got := station.Sufficient(toAddr, uint64(1)); got != want {
+		if got := station.Sufficient(toAddr, uint64(1), crypto.EmptyAddress); got != want {
 			t.Errorf("DummyStation.Sufficient() = %v, want %v", got, want)
 		}
 	})
@@ -41,7 +41,7 @@ func TestDummyStation_Burn(t *testing.T) {
 	pub, _, _ := ed25519.GenerateKey(rand.Reader)
 	addr := crypto.AddressFromPubKey(pub)
 	var want []*crypto.Event
-	if got := station.Burn(addr, uint64(0)); !cmp.Equal(got, want) {
+	if got := station.Burn(addr, uint64(0), uint32(0), crypto.EmptyAddress); !cmp.Equal(got, want) {
 		t.Errorf("DummyStation.Burn() = %v, want %v", got, want)
 	}
 }
@@ -87,6 +87,20 @@ func (app *DummyApp) GetGasContractToken() Token {
 	return nil
 }
 
+func (app *DummyApp) GetNativeToken() Token {
+	return nil
+}
+
+func (app *DummyApp) GetFeeToken(address crypto.Address) (Token, uint64) {
+	return nil, 0
+}
+
+func (app *DummyApp) SetFeeTokenCollected(collector crypto.Address, token crypto.Address, collected bool) {}
+
+func (app *DummyApp) CollectedFeeTokens(collector crypto.Address) []crypto.Address {
+	return nil
+}
+
 func TestNewDummyStation(t *testing.T) {
 	app := &DummyApp{}
 	want := &DummyStation{
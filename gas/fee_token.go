@@ -0,0 +1,26 @@
+package gas
+
+import (
+	"math/bits"
+)
+
+// RatePrecision is denominator of fee token rates,
+// a token with rate RatePrecision pays the same amount as default gas token
+const RatePrecision = uint64(1000000)
+
+// ConvertFee returns amount of fee token worth fee in default gas token, rounded up.
+// It returns false if amount overflows.
+func ConvertFee(fee uint64, rate uint64) (uint64, bool) {
+	hi, lo := bits.Mul64(fee, rate)
+	if hi >= RatePrecision {
+		return 0, false
+	}
+	amount, remainder := bits.Div64(hi, lo, RatePrecision)
+	if remainder > 0 {
+		if amount == ^uint64(0) {
+			return 0, false
+		}
+		amount++
+	}
+	return amount, true
+}
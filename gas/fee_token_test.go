@@ -0,0 +1,30 @@
+package gas
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvertFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		fee    uint64
+		rate   uint64
+		want   uint64
+		wantOk bool
+	}{
+		{"Same as gas token", 100, RatePrecision, 100, true},
+		{"Double", 100, 2 * RatePrecision, 200, true},
+		{"Round up", 1, RatePrecision / 3, 1, true},
+		{"Zero fee", 0, 3 * RatePrecision, 0, true},
+		{"Overflow", math.MaxUint64, 2 * RatePrecision, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ConvertFee(tt.fee, tt.rate)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ConvertFee() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
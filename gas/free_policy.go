@@ -19,6 +19,26 @@ func (p *FreePolicy) GetCostForStorage(size int) uint64 {
 	return 0
 }
 
+// GetCostForStorageWrite of data, no refund
+func (p *FreePolicy) GetCostForStorageWrite(oldSize, newSize int) (uint64, uint64) {
+	return 0, 0
+}
+
+// GetRefund nothing
+func (p *FreePolicy) GetRefund(gasUsed uint64, refund uint64) uint64 {
+	return 0
+}
+
+// GetRefundForSelfDestruct nothing
+func (p *FreePolicy) GetRefundForSelfDestruct() uint64 {
+	return 0
+}
+
+// GetCostForStorageRead size of data
+func (p *FreePolicy) GetCostForStorageRead(size int) uint64 {
+	return 0
+}
+
 // GetCostForContract creation
 func (p *FreePolicy) GetCostForContract(size int) uint64 {
 	return 0
@@ -33,3 +53,23 @@ func (p *FreePolicy) GetCostForEvent(size int) uint64 {
 func (p *FreePolicy) GetCostForMalloc(pages int) uint64 {
 	return 0
 }
+
+// GetCostForHash of data size
+func (p *FreePolicy) GetCostForHash(size int) uint64 {
+	return 0
+}
+
+// GetCostForSignatureVerify returns cost for a signature verification
+func (p *FreePolicy) GetCostForSignatureVerify() uint64 {
+	return 0
+}
+
+// GetCostForCall returns overhead for a cross-contract call
+func (p *FreePolicy) GetCostForCall() uint64 {
+	return 0
+}
+
+// GetCostForTransfer nothing
+func (p *FreePolicy) GetCostForTransfer() uint64 {
+	return 0
+}
@@ -28,4 +28,20 @@ func TestFreePolicy(t *testing.T) {
 	if cost != 0 {
 		t.Errorf("Expect cost %v, got %v", 0, cost)
 	}
+	cost = policy.GetCostForStorageRead(100)
+	if cost != 0 {
+		t.Errorf("Expect cost %v, got %v", 0, cost)
+	}
+	cost = policy.GetCostForHash(100)
+	if cost != 0 {
+		t.Errorf("Expect cost %v, got %v", 0, cost)
+	}
+	cost = policy.GetCostForSignatureVerify()
+	if cost != 0 {
+		t.Errorf("Expect cost %v, got %v", 0, cost)
+	}
+	cost = policy.GetCostForCall()
+	if cost != 0 {
+		t.Errorf("Expect cost %v, got %v", 0, cost)
+	}
 }
@@ -13,12 +13,12 @@ type FreeStation struct {
 }
 
 // Sufficient gas of an address is enough for burn
-func (station *FreeStation) Sufficient(addr crypto.Address, gas uint64) bool {
+func (station *FreeStation) Sufficient(addr crypto.Address, gas uint64, feeToken crypto.Address) bool {
 	return true
 }
 
 // Burn gas, do nothing
-func (station *FreeStation) Burn(addr crypto.Address, gas uint64) []*crypto.Event {
+func (station *FreeStation) Burn(addr crypto.Address, gasUsed uint64, price uint32, feeToken crypto.Address) []*crypto.Event {
 	return nil
 }
 
@@ -36,7 +36,7 @@ func (station *FreeStation) Switch() bool {
 		// Only activate if creator balance > 0 aka minted
 		if balance > 0 {
 			log.Println("Change to liquid station")
-			app.SetGasStation(NewLiquidStation(app, contract.GetAddress()))
+			app.SetGasStation(NewLiquidStation(app, FeeCollectorAddress))
 			return true
 		}
 	}
@@ -53,6 +53,22 @@ func (station *FreeStation) CheckGasPrice(price uint32) bool {
 	return price > 0
 }
 
+// SetSchedule does nothing, free station never charges
+func (station *FreeStation) SetSchedule(schedule *Schedule) {}
+
+// SetBaseFee does nothing, free station never charges
+func (station *FreeStation) SetBaseFee(baseFee uint32) {}
+
+// Distribute does nothing, free station never collects
+func (station *FreeStation) Distribute(distribution *Distribution) []*crypto.Event {
+	return nil
+}
+
+// ID of station
+func (station *FreeStation) ID() StationID {
+	return FreeStationID
+}
+
 // NewFreeStation constructor
 func NewFreeStation(app App) Station {
 	return &FreeStation{
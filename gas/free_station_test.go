@@ -79,7 +79,7 @@ func TestFreeSufficient(t *testing.T) {
 	app := &MockFreeApp{}
 	station := NewFreeStation(app)
 	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
-	ret := station.Sufficient(otherAddress, 10)
+	ret := station.Sufficient(otherAddress, 10, crypto.EmptyAddress)
 
 	if !ret {
 		t.Error("Expected return true")
@@ -90,9 +90,9 @@ func TestFreeBurn(t *testing.T) {
 	app := &MockFreeApp{}
 	station := NewFreeStation(app)
 	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
-	station.Burn(otherAddress, 10)
+	station.Burn(otherAddress, 10, 1, crypto.EmptyAddress)
 
-	ret := station.Burn(otherAddress, 0)
+	ret := station.Burn(otherAddress, 0, 1, crypto.EmptyAddress)
 	if ret != nil {
 		t.Error("Expected return nil")
 	}
@@ -4,38 +4,88 @@ import (
 	"github.com/QuoineFinancial/liquid-chain/crypto"
 )
 
-const minimumGasPrice = uint32(18)
 const feeTranferMemo = uint64(0)
 
-// LiquidStation provide a liquid as a gas station
+// LiquidStation provide a liquid as a gas station, in native mode fee is charged from native balance
 type LiquidStation struct {
-	app       App
-	policy    Policy
-	collector crypto.Address
+	app             App
+	policy          Policy
+	minimumGasPrice uint32
+	baseFee         uint32
+	collector       crypto.Address
+	native          bool
+}
+
+// gasToken is token charged when tx has no fee token
+func (station *LiquidStation) gasToken() Token {
+	if station.native {
+		return station.app.GetNativeToken()
+	}
+	return station.app.GetGasContractToken()
+}
+
+// feeToken resolves token paying fee and its rate, token is nil if not accepted
+func (station *LiquidStation) feeToken(address crypto.Address) (Token, uint64) {
+	if address == crypto.EmptyAddress {
+		return station.gasToken(), RatePrecision
+	}
+	return station.app.GetFeeToken(address)
 }
 
 // Sufficient gas of an address is enough for burn
-func (station *LiquidStation) Sufficient(addr crypto.Address, fee uint64) bool {
-	token := station.app.GetGasContractToken()
+func (station *LiquidStation) Sufficient(addr crypto.Address, fee uint64, feeToken crypto.Address) bool {
+	token, rate := station.feeToken(feeToken)
+	if token == nil {
+		return false
+	}
+	amount, ok := ConvertFee(fee, rate)
+	if !ok {
+		return false
+	}
 	balance, err := token.GetBalance(addr)
 	if err != nil {
 		panic(err)
 	}
-	return fee <= balance
+	return amount <= balance
 }
 
-// Burn gas
-func (station *LiquidStation) Burn(addr crypto.Address, fee uint64) []*crypto.Event {
-	token := station.app.GetGasContractToken()
-	// Move to gas owner
-	if fee > 0 {
-		events, err := token.Transfer(addr, station.collector, fee, feeTranferMemo)
+// Burn gas, base fee portion is burnt and only the tip goes to collector
+func (station *LiquidStation) Burn(addr crypto.Address, gasUsed uint64, price uint32, feeToken crypto.Address) []*crypto.Event {
+	token, rate := station.feeToken(feeToken)
+	if token == nil {
+		panic("fee token is not accepted")
+	}
+	baseFee := station.baseFee
+	if baseFee > price {
+		baseFee = price
+	}
+	total, ok := ConvertFee(gasUsed*uint64(price), rate)
+	if !ok {
+		panic("fee overflow")
+	}
+	burnt, _ := ConvertFee(gasUsed*uint64(baseFee), rate)
+	tip := total - burnt
+	if feeToken != crypto.EmptyAddress && tip > 0 {
+		station.app.SetFeeTokenCollected(station.collector, feeToken, true)
+	}
+
+	var events []*crypto.Event
+	if burnt > 0 {
+		burnEvents, err := token.Transfer(addr, BurnAddress, burnt, feeTranferMemo)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, burnEvents...)
+	}
+	// Move tip to gas owner
+	if tip > 0 {
+		tipEvents, err := token.Transfer(addr, station.collector, tip, feeTranferMemo)
 		if err != nil {
 			panic(err)
 		}
-		return events
+		events = append(events, tipEvents...)
 	}
-	return nil
+	return events
 }
 
 // Switch off fee, never call
@@ -50,14 +100,89 @@ func (station *LiquidStation) GetPolicy() Policy {
 
 // CheckGasPrice of transaction
 func (station *LiquidStation) CheckGasPrice(price uint32) bool {
-	return price >= minimumGasPrice
+	return price >= station.minimumGasPrice && price >= station.baseFee
+}
+
+// SetSchedule applies costs and minimum gas price of schedule
+func (station *LiquidStation) SetSchedule(schedule *Schedule) {
+	station.policy = NewAlphaPolicy(schedule)
+	station.minimumGasPrice = schedule.MinimumGasPrice
+}
+
+// SetBaseFee of current block
+func (station *LiquidStation) SetBaseFee(baseFee uint32) {
+	station.baseFee = baseFee
+}
+
+// Distribute pays out balance of collector in gas token, gas contract token and collected fee tokens,
+// fee tokens are kept collected for next distribution while their balance has nobody to be paid to
+func (station *LiquidStation) Distribute(distribution *Distribution) []*crypto.Event {
+	var events []*crypto.Event
+	// Gas contract may be gone, such as self destructed while fee was paid in native balance
+	if gasToken := station.gasToken(); gasToken != nil {
+		events, _ = station.payOut(gasToken, distribution)
+	}
+	// Storage rent is paid in gas contract token in native mode too
+	if station.native {
+		if rentToken := station.app.GetGasContractToken(); rentToken != nil {
+			rentEvents, _ := station.payOut(rentToken, distribution)
+			events = append(events, rentEvents...)
+		}
+	}
+	for _, address := range station.app.CollectedFeeTokens(station.collector) {
+		token, _ := station.app.GetFeeToken(address)
+		if token == nil {
+			station.app.SetFeeTokenCollected(station.collector, address, false)
+			continue
+		}
+		tokenEvents, paid := station.payOut(token, distribution)
+		if paid {
+			station.app.SetFeeTokenCollected(station.collector, address, false)
+		}
+		events = append(events, tokenEvents...)
+	}
+	return events
+}
+
+// payOut transfers balance of collector in token by distribution, paid is false when balance is left for nobody
+func (station *LiquidStation) payOut(token Token, distribution *Distribution) ([]*crypto.Event, bool) {
+	balance, err := token.GetBalance(station.collector)
+	if err != nil {
+		panic(err)
+	}
+	payouts := distribution.Payouts(balance)
+	var events []*crypto.Event
+	for _, payout := range payouts {
+		payoutEvents, err := token.Transfer(station.collector, payout.Address, payout.Amount, feeTranferMemo)
+		if err != nil {
+			panic(err)
+		}
+		events = append(events, payoutEvents...)
+	}
+	return events, balance == 0 || len(payouts) > 0
+}
+
+// ID of station
+func (station *LiquidStation) ID() StationID {
+	if station.native {
+		return NativeStationID
+	}
+	return LiquidStationID
 }
 
 // NewLiquidStation with fee
 func NewLiquidStation(app App, collector crypto.Address) Station {
 	return &LiquidStation{
-		app:       app,
-		policy:    &AlphaPolicy{},
-		collector: collector,
+		app:             app,
+		policy:          &AlphaPolicy{},
+		minimumGasPrice: defaultSchedule.MinimumGasPrice,
+		collector:       collector,
 	}
 }
+
+// NewNativeStation charges fee from native balance, fee tokens are still accepted
+func NewNativeStation(app App, collector crypto.Address) Station {
+	station := NewLiquidStation(app, collector).(*LiquidStation)
+	station.native = true
+	return station
+}
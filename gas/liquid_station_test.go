@@ -58,13 +58,13 @@ func TestSufficient(t *testing.T) {
 	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
 
 	station := NewLiquidStation(app, contractAddress)
-	ret := station.Sufficient(otherAddress, 10)
+	ret := station.Sufficient(otherAddress, 10, crypto.EmptyAddress)
 
 	if !ret {
 		t.Error("Expected return true")
 	}
 
-	ret = station.Sufficient(otherAddress, 1000)
+	ret = station.Sufficient(otherAddress, 1000, crypto.EmptyAddress)
 
 	if ret {
 		t.Error("Expected return false")
@@ -78,9 +78,9 @@ func TestBurn(t *testing.T) {
 
 	station := NewLiquidStation(app, contractAddress)
 
-	station.Burn(otherAddress, 10)
+	station.Burn(otherAddress, 10, 1, crypto.EmptyAddress)
 
-	ret := station.Burn(otherAddress, 0)
+	ret := station.Burn(otherAddress, 0, 1, crypto.EmptyAddress)
 	if ret != nil {
 		t.Error("Expected return nil")
 	}
@@ -90,7 +90,7 @@ func TestBurn(t *testing.T) {
 			t.Errorf("The code did not panic")
 		}
 	}()
-	station.Burn(otherAddress, 10000)
+	station.Burn(otherAddress, 10000, 1, crypto.EmptyAddress)
 }
 
 func TestCheckGasPrice(t *testing.T) {
@@ -114,3 +114,225 @@ func TestCheckGasPrice(t *testing.T) {
 		t.Error("Expected return true")
 	}
 }
+
+func TestSetSchedule(t *testing.T) {
+	app := &MockApp{}
+	contractAddress, _ := crypto.AddressFromString(contractAddressStr)
+	station := NewLiquidStation(app, contractAddress)
+
+	schedule := DefaultSchedule()
+	schedule.MinimumGasPrice = 30
+	schedule.ContractByte = 5
+	station.SetSchedule(schedule)
+
+	if station.CheckGasPrice(29) {
+		t.Error("Expected return false")
+	}
+
+	if !station.CheckGasPrice(30) {
+		t.Error("Expected return true")
+	}
+
+	if cost := station.GetPolicy().GetCostForContract(10); cost != 50 {
+		t.Errorf("Expect cost %v, got %v", 50, cost)
+	}
+}
+
+type MockRecordToken struct {
+	Token
+	balance   uint64
+	transfers map[crypto.Address]uint64
+}
+
+func (token *MockRecordToken) GetBalance(addr crypto.Address) (uint64, error) {
+	return token.balance, nil
+}
+
+func (token *MockRecordToken) Transfer(caller crypto.Address, addr crypto.Address, amount uint64, memo uint64) ([]*crypto.Event, error) {
+	token.transfers[addr] += amount
+	return []*crypto.Event{{Contract: addr}}, nil
+}
+
+type MockRecordApp struct {
+	App
+	token     *MockRecordToken
+	native    *MockRecordToken
+	feeTokens map[crypto.Address]*MockRecordToken
+	feeRates  map[crypto.Address]uint64
+	collected map[crypto.Address]bool
+}
+
+func (app *MockRecordApp) SetFeeTokenCollected(collector crypto.Address, token crypto.Address, collected bool) {
+	if app.collected == nil {
+		app.collected = make(map[crypto.Address]bool)
+	}
+	if collected {
+		app.collected[token] = true
+	} else {
+		delete(app.collected, token)
+	}
+}
+
+func (app *MockRecordApp) CollectedFeeTokens(collector crypto.Address) []crypto.Address {
+	var tokens []crypto.Address
+	for token := range app.collected {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+func (app *MockRecordApp) GetGasContractToken() Token {
+	return app.token
+}
+
+func (app *MockRecordApp) GetNativeToken() Token {
+	return app.native
+}
+
+func (app *MockRecordApp) GetFeeToken(address crypto.Address) (Token, uint64) {
+	if token, ok := app.feeTokens[address]; ok {
+		return token, app.feeRates[address]
+	}
+	return nil, 0
+}
+
+func TestBurnBaseFee(t *testing.T) {
+	app := &MockRecordApp{token: &MockRecordToken{transfers: make(map[crypto.Address]uint64)}}
+	contractAddress, _ := crypto.AddressFromString(contractAddressStr)
+	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
+	station := NewLiquidStation(app, contractAddress)
+	station.SetBaseFee(20)
+
+	if station.CheckGasPrice(19) {
+		t.Error("Expected return false")
+	}
+
+	events := station.Burn(otherAddress, 10, 25, crypto.EmptyAddress)
+	if len(events) != 2 {
+		t.Errorf("Expect %v events, got %v", 2, len(events))
+	}
+	if burnt := app.token.transfers[BurnAddress]; burnt != 200 {
+		t.Errorf("Expect burnt %v, got %v", 200, burnt)
+	}
+	if tip := app.token.transfers[contractAddress]; tip != 50 {
+		t.Errorf("Expect tip %v, got %v", 50, tip)
+	}
+}
+
+func TestNativeStation(t *testing.T) {
+	app := &MockRecordApp{
+		token:  &MockRecordToken{transfers: make(map[crypto.Address]uint64)},
+		native: &MockRecordToken{balance: 300, transfers: make(map[crypto.Address]uint64)},
+	}
+	contractAddress, _ := crypto.AddressFromString(contractAddressStr)
+	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
+	station := NewNativeStation(app, contractAddress)
+	station.SetBaseFee(20)
+	if station.ID() != NativeStationID {
+		t.Errorf("Expect station %v, got %v", NativeStationID, station.ID())
+	}
+
+	if !station.Sufficient(otherAddress, 300, crypto.EmptyAddress) || station.Sufficient(otherAddress, 301, crypto.EmptyAddress) {
+		t.Error("Expect fee to be limited by native balance")
+	}
+	station.Burn(otherAddress, 10, 25, crypto.EmptyAddress)
+	if burnt := app.native.transfers[BurnAddress]; burnt != 200 {
+		t.Errorf("Expect burnt %v, got %v", 200, burnt)
+	}
+	if tip := app.native.transfers[contractAddress]; tip != 50 {
+		t.Errorf("Expect tip %v, got %v", 50, tip)
+	}
+	if len(app.token.transfers) != 0 {
+		t.Errorf("Expect gas contract token untouched, got %v", app.token.transfers)
+	}
+
+	// Rent collected in gas contract token is distributed with native fees
+	app.token.balance = 100
+	proposer := crypto.NewDeploymentAddress(crypto.EmptyAddress, 102)
+	events := station.Distribute(&Distribution{Proposer: &proposer, ProposerPercent: 100})
+	if len(events) != 2 {
+		t.Errorf("Expect %v events, got %v", 2, len(events))
+	}
+	if paid := app.native.transfers[proposer]; paid != 300 {
+		t.Errorf("Expect proposer paid %v native, got %v", 300, paid)
+	}
+	if paid := app.token.transfers[proposer]; paid != 100 {
+		t.Errorf("Expect proposer paid %v rent, got %v", 100, paid)
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	app := &MockRecordApp{token: &MockRecordToken{balance: 1000, transfers: make(map[crypto.Address]uint64)}}
+	proposer, _ := crypto.AddressFromString(contractAddressStr)
+	validator, _ := crypto.AddressFromString(otherAddressStr)
+	station := NewLiquidStation(app, FeeCollectorAddress)
+
+	events := station.Distribute(&Distribution{
+		Proposer:        &proposer,
+		ProposerPercent: 20,
+		Validators:      []*FeeShare{{Address: validator, Power: 10}},
+	})
+	if len(events) != 2 {
+		t.Errorf("Expect %v events, got %v", 2, len(events))
+	}
+	if paid := app.token.transfers[proposer]; paid != 200 {
+		t.Errorf("Expect proposer paid %v, got %v", 200, paid)
+	}
+	if paid := app.token.transfers[validator]; paid != 800 {
+		t.Errorf("Expect validator paid %v, got %v", 800, paid)
+	}
+}
+
+func TestFeeToken(t *testing.T) {
+	feeTokenAddress := crypto.NewDeploymentAddress(crypto.EmptyAddress, 100)
+	unknownTokenAddress := crypto.NewDeploymentAddress(crypto.EmptyAddress, 101)
+	feeToken := &MockRecordToken{balance: 1000, transfers: make(map[crypto.Address]uint64)}
+	app := &MockRecordApp{
+		token:     &MockRecordToken{transfers: make(map[crypto.Address]uint64)},
+		feeTokens: map[crypto.Address]*MockRecordToken{feeTokenAddress: feeToken},
+		feeRates:  map[crypto.Address]uint64{feeTokenAddress: 2 * RatePrecision},
+	}
+	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
+	station := NewLiquidStation(app, FeeCollectorAddress)
+	station.SetBaseFee(20)
+
+	if station.Sufficient(otherAddress, 10, unknownTokenAddress) {
+		t.Error("Expected return false for unknown token")
+	}
+	if !station.Sufficient(otherAddress, 500, feeTokenAddress) {
+		t.Error("Expected return true")
+	}
+	if station.Sufficient(otherAddress, 501, feeTokenAddress) {
+		t.Error("Expected return false")
+	}
+
+	station.Burn(otherAddress, 10, 25, feeTokenAddress)
+	if burnt := feeToken.transfers[BurnAddress]; burnt != 400 {
+		t.Errorf("Expect burnt %v, got %v", 400, burnt)
+	}
+	if tip := feeToken.transfers[FeeCollectorAddress]; tip != 100 {
+		t.Errorf("Expect tip %v, got %v", 100, tip)
+	}
+
+	// Fee token is kept while there is nobody to pay
+	if events := station.Distribute(nil); len(events) != 0 {
+		t.Errorf("Expect no events, got %v", len(events))
+	}
+
+	// Collected fee token is distributed along with gas contract token,
+	// it is kept in app state so a station constructed after restart pays it out
+	station = NewLiquidStation(app, FeeCollectorAddress)
+	proposer := crypto.NewDeploymentAddress(crypto.EmptyAddress, 102)
+	events := station.Distribute(&Distribution{Proposer: &proposer, ProposerPercent: 100})
+	if len(events) != 1 {
+		t.Errorf("Expect %v events, got %v", 1, len(events))
+	}
+	if paid := feeToken.transfers[proposer]; paid != 1000 {
+		t.Errorf("Expect proposer paid %v, got %v", 1000, paid)
+	}
+
+	// Paid out fee token is dropped until it collects again
+	if events := station.Distribute(&Distribution{Proposer: &proposer, ProposerPercent: 100}); len(events) != 0 {
+		t.Errorf("Expect no events, got %v", len(events))
+	}
+}
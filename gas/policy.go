@@ -8,6 +8,14 @@ import (
 type Policy interface {
 	vm.GasPolicy
 	GetCostForStorage(size int) uint64
+	GetCostForStorageWrite(oldSize, newSize int) (uint64, uint64)
+	GetRefund(gasUsed uint64, refund uint64) uint64
+	GetRefundForSelfDestruct() uint64
+	GetCostForStorageRead(size int) uint64
 	GetCostForContract(size int) uint64
 	GetCostForEvent(size int) uint64
+	GetCostForHash(size int) uint64
+	GetCostForSignatureVerify() uint64
+	GetCostForCall() uint64
+	GetCostForTransfer() uint64
 }
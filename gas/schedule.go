@@ -0,0 +1,143 @@
+package gas
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/vertexdlt/vertexvm/opcode"
+)
+
+// GenesisScheduleName is name of the schedule active from genesis
+const GenesisScheduleName = "genesis"
+
+// Schedule is a configurable set of gas costs and price floor
+type Schedule struct {
+	Ops                map[opcode.Opcode]uint64 `json:"ops,omitempty"`
+	MemoryPage         uint64                   `json:"memoryPage"`
+	StorageByte        uint64                   `json:"storageByte"`
+	StorageSlot        uint64                   `json:"storageSlot"`
+	StorageSlotRefund  uint64                   `json:"storageSlotRefund"`
+	StorageByteRefund  uint64                   `json:"storageByteRefund"`
+	SelfDestructRefund uint64                   `json:"selfDestructRefund"`
+	MaxRefundQuotient  uint64                   `json:"maxRefundQuotient"`
+	StorageRead        uint64                   `json:"storageRead"`
+	StorageReadByte    uint64                   `json:"storageReadByte"`
+	ContractByte       uint64                   `json:"contractByte"`
+	EventByte          uint64                   `json:"eventByte"`
+	Hash               uint64                   `json:"hash"`
+	HashByte           uint64                   `json:"hashByte"`
+	SignatureVerify    uint64                   `json:"signatureVerify"`
+	Call               uint64                   `json:"call"`
+	Transfer           uint64                   `json:"transfer"`
+	MinimumGasPrice    uint32                   `json:"minimumGasPrice"`
+}
+
+// DefaultSchedule returns costs of the first version
+func DefaultSchedule() *Schedule {
+	return &Schedule{
+		MemoryPage:         GasMemoryPage,
+		StorageByte:        1,
+		StorageSlot:        GasStorageSlot,
+		StorageSlotRefund:  GasStorageSlotRefund,
+		StorageByteRefund:  1,
+		SelfDestructRefund: GasSelfDestructRefund,
+		MaxRefundQuotient:  2,
+		StorageRead:        GasStorageRead,
+		StorageReadByte:    1,
+		ContractByte:       1,
+		EventByte:          1,
+		Hash:               GasHash,
+		HashByte:           1,
+		SignatureVerify:    GasSignatureVerify,
+		Call:               GasCall,
+		Transfer:           GasTransfer,
+		MinimumGasPrice:    18,
+	}
+}
+
+func (schedule *Schedule) copy() *Schedule {
+	copied := *schedule
+	if schedule.Ops != nil {
+		copied.Ops = make(map[opcode.Opcode]uint64, len(schedule.Ops))
+		for op, cost := range schedule.Ops {
+			copied.Ops[op] = cost
+		}
+	}
+	return &copied
+}
+
+// Upgrade returns a new schedule with changes applied, omitted fields keep current values
+func (schedule *Schedule) Upgrade(changes json.RawMessage) (*Schedule, error) {
+	upgraded := schedule.copy()
+	if len(changes) == 0 {
+		return upgraded, nil
+	}
+	if err := json.Unmarshal(changes, upgraded); err != nil {
+		return nil, err
+	}
+	return upgraded, nil
+}
+
+// ScheduleUpgrade is a named change of schedule activated from Height
+type ScheduleUpgrade struct {
+	Name    string          `json:"name"`
+	Height  uint64          `json:"height"`
+	Changes json.RawMessage `json:"schedule"`
+}
+
+type activation struct {
+	name     string
+	height   uint64
+	schedule *Schedule
+}
+
+// Schedules resolves the schedule in effect at a block height
+type Schedules struct {
+	activations []activation
+}
+
+// NewSchedules applies upgrades in height order on top of genesis schedule
+func NewSchedules(genesis *Schedule, upgrades []*ScheduleUpgrade) (*Schedules, error) {
+	if genesis == nil {
+		genesis = DefaultSchedule()
+	}
+	sorted := make([]*ScheduleUpgrade, len(upgrades))
+	copy(sorted, upgrades)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Height < sorted[j].Height
+	})
+
+	schedules := &Schedules{
+		activations: []activation{{GenesisScheduleName, 0, genesis.copy()}},
+	}
+	names := map[string]bool{GenesisScheduleName: true}
+	for _, upgrade := range sorted {
+		if names[upgrade.Name] {
+			return nil, fmt.Errorf("duplicated gas schedule %s", upgrade.Name)
+		}
+		last := schedules.activations[len(schedules.activations)-1]
+		if upgrade.Height <= last.height {
+			return nil, fmt.Errorf("gas schedule %s must activate after height %d", upgrade.Name, last.height)
+		}
+		schedule, err := last.schedule.Upgrade(upgrade.Changes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gas schedule %s: %v", upgrade.Name, err)
+		}
+		names[upgrade.Name] = true
+		schedules.activations = append(schedules.activations, activation{upgrade.Name, upgrade.Height, schedule})
+	}
+	return schedules, nil
+}
+
+// At returns name and schedule in effect at height
+func (schedules *Schedules) At(height uint64) (string, *Schedule) {
+	active := schedules.activations[0]
+	for _, activation := range schedules.activations[1:] {
+		if activation.height > height {
+			break
+		}
+		active = activation
+	}
+	return active.name, active.schedule
+}
@@ -0,0 +1,79 @@
+package gas
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vertexdlt/vertexvm/opcode"
+)
+
+func TestScheduleUpgrade(t *testing.T) {
+	schedule := DefaultSchedule()
+	upgraded, err := schedule.Upgrade(json.RawMessage(`{"storageByte": 10, "ops": {"27": 7}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgraded.StorageByte != 10 {
+		t.Errorf("Expect storageByte %v, got %v", 10, upgraded.StorageByte)
+	}
+	if upgraded.ContractByte != schedule.ContractByte {
+		t.Errorf("Expect contractByte %v, got %v", schedule.ContractByte, upgraded.ContractByte)
+	}
+	if upgraded.Ops[opcode.Select] != 7 {
+		t.Errorf("Expect select cost %v, got %v", 7, upgraded.Ops[opcode.Select])
+	}
+	if schedule.StorageByte != 1 || len(schedule.Ops) != 0 {
+		t.Error("Expect original schedule unchanged")
+	}
+
+	if _, err := schedule.Upgrade(json.RawMessage(`{"ops": {"256": 1}}`)); err == nil {
+		t.Error("Expect error for invalid opcode")
+	}
+}
+
+func TestNewSchedules(t *testing.T) {
+	upgrades := []*ScheduleUpgrade{
+		{Name: "storage-v2", Height: 20, Changes: json.RawMessage(`{"storageByte": 3}`)},
+		{Name: "price-v1", Height: 10, Changes: json.RawMessage(`{"minimumGasPrice": 20}`)},
+	}
+	schedules, err := NewSchedules(nil, upgrades)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		height      uint64
+		name        string
+		storageByte uint64
+		gasPrice    uint32
+	}{
+		{0, GenesisScheduleName, 1, 18},
+		{9, GenesisScheduleName, 1, 18},
+		{10, "price-v1", 1, 20},
+		{25, "storage-v2", 3, 20},
+	}
+	for _, tt := range tests {
+		name, schedule := schedules.At(tt.height)
+		if name != tt.name {
+			t.Errorf("At(%d) name = %v, want %v", tt.height, name, tt.name)
+		}
+		if schedule.StorageByte != tt.storageByte {
+			t.Errorf("At(%d) storageByte = %v, want %v", tt.height, schedule.StorageByte, tt.storageByte)
+		}
+		if schedule.MinimumGasPrice != tt.gasPrice {
+			t.Errorf("At(%d) minimumGasPrice = %v, want %v", tt.height, schedule.MinimumGasPrice, tt.gasPrice)
+		}
+	}
+}
+
+func TestNewSchedulesInvalid(t *testing.T) {
+	if _, err := NewSchedules(nil, []*ScheduleUpgrade{{Name: GenesisScheduleName, Height: 1}}); err == nil {
+		t.Error("Expect error for duplicated name")
+	}
+	if _, err := NewSchedules(nil, []*ScheduleUpgrade{{Name: "v1", Height: 0}}); err == nil {
+		t.Error("Expect error for upgrade at genesis height")
+	}
+	if _, err := NewSchedules(nil, []*ScheduleUpgrade{{Name: "v1", Height: 5}, {Name: "v2", Height: 5}}); err == nil {
+		t.Error("Expect error for upgrades at same height")
+	}
+}
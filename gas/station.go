@@ -1,17 +1,75 @@
 package gas
 
 import (
+	"fmt"
+
 	"github.com/QuoineFinancial/liquid-chain/crypto"
 	"github.com/QuoineFinancial/liquid-chain/storage"
 )
 
 // Station interface for check and burn gas
 type Station interface {
-	Sufficient(addr crypto.Address, gas uint64) bool
-	Burn(addr crypto.Address, gas uint64) []*crypto.Event
+	Sufficient(addr crypto.Address, fee uint64, feeToken crypto.Address) bool
+	Burn(addr crypto.Address, gasUsed uint64, price uint32, feeToken crypto.Address) []*crypto.Event
 	CheckGasPrice(price uint32) bool
 	Switch() bool
 	GetPolicy() Policy
+	SetSchedule(schedule *Schedule)
+	SetBaseFee(baseFee uint32)
+	Distribute(distribution *Distribution) []*crypto.Event
+	ID() StationID
+}
+
+// StationID identifies kind of gas station
+type StationID byte
+
+// StationID values
+const (
+	FreeStationID   StationID = 0x0
+	LiquidStationID StationID = 0x1
+	DummyStationID  StationID = 0x2
+	NativeStationID StationID = 0x3
+)
+
+var stationNames = map[StationID]string{
+	FreeStationID:   "free",
+	LiquidStationID: "liquid",
+	DummyStationID:  "dummy",
+	NativeStationID: "native",
+}
+
+func (id StationID) String() string {
+	return stationNames[id]
+}
+
+// Selectable checks whether station can be requested by governance or genesis,
+// dummy station rejects every gas price so it is only used in tests
+func (id StationID) Selectable() bool {
+	return id == FreeStationID || id == LiquidStationID || id == NativeStationID
+}
+
+// ParseStationID returns id of selectable station with given name
+func ParseStationID(name string) (StationID, error) {
+	for id, stationName := range stationNames {
+		if stationName == name && id.Selectable() {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown gas station %s", name)
+}
+
+// NewStation constructs a station of given kind
+func NewStation(id StationID, app App) Station {
+	switch id {
+	case LiquidStationID:
+		return NewLiquidStation(app, FeeCollectorAddress)
+	case DummyStationID:
+		return NewDummyStation(app)
+	case NativeStationID:
+		return NewNativeStation(app, FeeCollectorAddress)
+	default:
+		return NewFreeStation(app)
+	}
 }
 
 // Token interface
@@ -21,8 +79,14 @@ type Token interface {
 	GetContract() *storage.Account
 }
 
-// App interface
+// App interface, fee token address crypto.EmptyAddress stands for gas contract token
+// or native balance in native gas mode. Fee tokens held by collector are kept in state
+// so stations of every node pay out the same tokens.
 type App interface {
 	SetGasStation(gasStation Station)
 	GetGasContractToken() Token
+	GetNativeToken() Token
+	GetFeeToken(address crypto.Address) (Token, uint64)
+	SetFeeTokenCollected(collector crypto.Address, token crypto.Address, collected bool)
+	CollectedFeeTokens(collector crypto.Address) []crypto.Address
 }
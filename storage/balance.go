@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"errors"
+	"math"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// ErrInsufficientBalance is returned when native balance cannot cover a transfer
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// ErrBalanceOverflow is returned when a transfer would overflow native balance of receiver
+var ErrBalanceOverflow = errors.New("balance overflow")
+
+// GetBalance returns native balance of address, zero if account does not exist
+func (state *StateStorage) GetBalance(address crypto.Address) (uint64, error) {
+	account, err := state.LoadAccount(address)
+	if err != nil || account == nil {
+		return 0, err
+	}
+	return account.Balance, nil
+}
+
+// AddBalance credits native balance of address, creating account if needed
+func (state *StateStorage) AddBalance(address crypto.Address, amount uint64) error {
+	account, err := state.LoadAccount(address)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		if account, err = state.CreateAccount(address, address, nil); err != nil {
+			return err
+		}
+	}
+	if account.Balance > math.MaxUint64-amount {
+		return ErrBalanceOverflow
+	}
+	account.dirty = true
+	account.Balance += amount
+	return nil
+}
+
+// Transfer moves native balance between addresses, nothing changes if it fails
+func (state *StateStorage) Transfer(from, to crypto.Address, amount uint64) error {
+	if amount == 0 {
+		return nil
+	}
+	sender, err := state.LoadAccount(from)
+	if err != nil {
+		return err
+	}
+	if sender == nil || sender.Balance < amount {
+		return ErrInsufficientBalance
+	}
+	if from == to {
+		return nil
+	}
+	if balance, err := state.GetBalance(to); err != nil {
+		return err
+	} else if balance > math.MaxUint64-amount {
+		return ErrBalanceOverflow
+	}
+	sender.dirty = true
+	sender.Balance -= amount
+	return state.AddBalance(to, amount)
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"encoding/binary"
+	"fmt"
 	"time"
 
 	"github.com/QuoineFinancial/liquid-chain/common"
@@ -12,14 +14,22 @@ import (
 // ChainStorage is storage for block
 type ChainStorage struct {
 	db.Database
-	txTrie       *trie.Trie
-	receiptTrie  *trie.Trie
-	CurrentBlock *crypto.Block
+	txTrie            *trie.Trie
+	receiptTrie       *trie.Trie
+	systemReceiptTrie *trie.Trie
+	CurrentBlock      *crypto.Block
 }
 
 // NewChainStorage returns new instance of IndexStorage
 func NewChainStorage(db db.Database) *ChainStorage {
-	return &ChainStorage{db, nil, nil, nil}
+	return &ChainStorage{db, nil, nil, nil, nil}
+}
+
+// indexKey is trie key of tx, receipt or system receipt at index, big endian keeps trie iteration in execution order
+func indexKey(index uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, index)
+	return key
 }
 
 // ComposeBlock compose currentBlock based on parent and proposed time
@@ -37,6 +47,12 @@ func (bs *ChainStorage) ComposeBlock(parent *crypto.Block, time time.Time) {
 	} else {
 		bs.receiptTrie = receiptTrie
 	}
+
+	if systemReceiptTrie, err := trie.New(common.EmptyHash, bs.Database); err != nil {
+		panic(err)
+	} else {
+		bs.systemReceiptTrie = systemReceiptTrie
+	}
 }
 
 // Commit puts currentBlock to storage
@@ -61,6 +77,15 @@ func (bs *ChainStorage) Commit(stateRoot common.Hash) common.Hash {
 	}
 	bs.CurrentBlock.SetReceiptRoot(receiptRoot)
 
+	// Blocks without system receipts keep empty root
+	if len(bs.CurrentBlock.SystemReceipts()) > 0 {
+		systemReceiptRoot, err := bs.systemReceiptTrie.Commit()
+		if err != nil {
+			panic(err)
+		}
+		bs.CurrentBlock.SetSystemReceiptRoot(systemReceiptRoot)
+	}
+
 	// Store block
 	hash := bs.CurrentBlock.Hash()
 	rawBlock, err := bs.CurrentBlock.Encode()
@@ -78,21 +103,38 @@ func (bs *ChainStorage) AddTransactionWithReceipt(tx *crypto.Transaction, receip
 		panic("ChainStorage.currentBlock is nil")
 	}
 
+	receipt.Index = uint32(len(bs.CurrentBlock.Receipts()))
 	rawTx, err := tx.Encode()
 	if err != nil {
 		return err
 	}
-	bs.txTrie.Update(tx.Hash().Bytes(), rawTx)
+	bs.txTrie.Update(indexKey(receipt.Index), rawTx)
 	bs.CurrentBlock.AddTransactions(tx)
 
-	receipt.Index = uint32(len(bs.CurrentBlock.Receipts()))
 	rawReceipt, err := receipt.Encode()
 	if err != nil {
 		return err
 	}
-	bs.receiptTrie.Update(receipt.Hash().Bytes(), rawReceipt)
+	bs.receiptTrie.Update(indexKey(receipt.Index), rawReceipt)
 
 	bs.CurrentBlock.AddReceipts(receipt)
+	bs.CurrentBlock.AddGasUsed(uint64(receipt.GasUsed))
+	return nil
+}
+
+// AddSystemReceipt add receipt of changes made by currentBlock outside of transactions
+func (bs *ChainStorage) AddSystemReceipt(receipt *crypto.Receipt) error {
+	if bs.CurrentBlock == nil {
+		panic("ChainStorage.currentBlock is nil")
+	}
+
+	receipt.Index = uint32(len(bs.CurrentBlock.SystemReceipts()))
+	rawReceipt, err := receipt.Encode()
+	if err != nil {
+		return err
+	}
+	bs.systemReceiptTrie.Update(indexKey(receipt.Index), rawReceipt)
+	bs.CurrentBlock.AddSystemReceipts(receipt)
 	return nil
 }
 
@@ -114,7 +156,7 @@ func (bs *ChainStorage) MustGetBlock(hash common.Hash) *crypto.Block {
 	return block
 }
 
-// GetBlockTransactions returns transactions of given block
+// GetBlockTransactions returns transactions of given block in execution order
 func (bs *ChainStorage) GetBlockTransactions(block *crypto.Block) ([]*crypto.Transaction, error) {
 	txTrie, err := trie.New(block.TransactionRoot, bs.Database)
 	if err != nil {
@@ -132,7 +174,7 @@ func (bs *ChainStorage) GetBlockTransactions(block *crypto.Block) ([]*crypto.Tra
 	return txs, nil
 }
 
-// GetBlockReceipts returns transactions of given block
+// GetBlockReceipts returns receipts of given block in execution order
 func (bs *ChainStorage) GetBlockReceipts(block *crypto.Block) ([]*crypto.Receipt, error) {
 	receiptTrie, err := trie.New(block.ReceiptRoot, bs.Database)
 	if err != nil {
@@ -149,3 +191,84 @@ func (bs *ChainStorage) GetBlockReceipts(block *crypto.Block) ([]*crypto.Receipt
 	}
 	return receipts, nil
 }
+
+// GetSystemReceipts returns system receipts of given block in execution order
+func (bs *ChainStorage) GetSystemReceipts(block *crypto.Block) ([]*crypto.Receipt, error) {
+	receipts := []*crypto.Receipt{}
+	if block.SystemReceiptRoot == common.EmptyHash {
+		return receipts, nil
+	}
+	systemReceiptTrie, err := trie.New(block.SystemReceiptRoot, bs.Database)
+	if err != nil {
+		return nil, err
+	}
+	iterator := trie.NewIterator(systemReceiptTrie.NodeIterator(nil))
+	for iterator.Next() {
+		receipt, err := crypto.DecodeReceipt(iterator.Value)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// GetTransactionWithReceipt returns tx at index of given block with its receipt
+func (bs *ChainStorage) GetTransactionWithReceipt(block *crypto.Block, index uint32) (*crypto.Transaction, *crypto.Receipt, error) {
+	rawTx, err := bs.getIndexed(block.TransactionRoot, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rawTx == nil {
+		return nil, nil, fmt.Errorf("transaction %d not found in block %d", index, block.Height)
+	}
+	tx, err := crypto.DecodeTransaction(rawTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawReceipt, err := bs.getIndexed(block.ReceiptRoot, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	receipt, err := crypto.DecodeReceipt(rawReceipt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, receipt, nil
+}
+
+// ProveTransaction returns Merkle proofs of tx at index of given block and of its receipt,
+// verified against TransactionRoot and ReceiptRoot of block with the index as key
+func (bs *ChainStorage) ProveTransaction(block *crypto.Block, index uint32) ([][]byte, [][]byte, error) {
+	if rawTx, err := bs.getIndexed(block.TransactionRoot, index); err != nil {
+		return nil, nil, err
+	} else if rawTx == nil {
+		return nil, nil, fmt.Errorf("transaction %d not found in block %d", index, block.Height)
+	}
+	txProof, err := bs.proveIndexed(block.TransactionRoot, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	receiptProof, err := bs.proveIndexed(block.ReceiptRoot, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return txProof, receiptProof, nil
+}
+
+func (bs *ChainStorage) getIndexed(root common.Hash, index uint32) ([]byte, error) {
+	indexTrie, err := trie.New(root, bs.Database)
+	if err != nil {
+		return nil, err
+	}
+	return indexTrie.Get(indexKey(index))
+}
+
+func (bs *ChainStorage) proveIndexed(root common.Hash, index uint32) ([][]byte, error) {
+	indexTrie, err := trie.New(root, bs.Database)
+	if err != nil {
+		return nil, err
+	}
+	return indexTrie.Prove(indexKey(index))
+}
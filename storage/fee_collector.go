@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"bytes"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// collectedTokenPrefix keys fee tokens in storage of collector account
+var collectedTokenPrefix = []byte("collected")
+
+func collectedTokenKey(token crypto.Address) []byte {
+	key := make([]byte, 0, len(collectedTokenPrefix)+crypto.AddressLength)
+	key = append(key, collectedTokenPrefix...)
+	return append(key, token[:]...)
+}
+
+// SetTokenCollected records whether collector holds balance of fee token
+func (state *StateStorage) SetTokenCollected(collector crypto.Address, token crypto.Address, collected bool) error {
+	account, err := state.LoadAccount(collector)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		if !collected {
+			return nil
+		}
+		if account, err = state.CreateAccount(collector, collector, nil); err != nil {
+			return err
+		}
+	}
+	var value []byte
+	if collected {
+		value = []byte{1}
+	}
+	return account.SetStorage(collectedTokenKey(token), value)
+}
+
+// CollectedTokens returns fee tokens collector holds balance of, ordered by address
+func (state *StateStorage) CollectedTokens(collector crypto.Address) ([]crypto.Address, error) {
+	account, err := state.LoadAccount(collector)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	var tokens []crypto.Address
+	it := account.StorageIterator(collectedTokenPrefix)
+	for it.Next() {
+		if !bytes.HasPrefix(it.Key, collectedTokenPrefix) {
+			break
+		}
+		token, err := crypto.AddressFromBytes(it.Key[len(collectedTokenPrefix):])
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, it.Err
+}
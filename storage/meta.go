@@ -14,6 +14,19 @@ var (
 	ErrTransactionNotFound = errors.New("transaction not found")
 )
 
+// Entry of address transactions is tx hash, roles of address, block height and tx index
+const addressTxEntryLength = common.HashLength + 1 + 8 + 4
+
+// AddressRole is bit set of ways a tx involves an address
+type AddressRole byte
+
+// Roles of address in tx
+const (
+	AddressRoleSender AddressRole = 1 << iota
+	AddressRoleReceiver
+	AddressRoleEvent
+)
+
 // MetaStorage is storage of indexes
 type MetaStorage struct {
 	db.Database
@@ -33,17 +46,17 @@ func (ms *MetaStorage) StoreBlockMetas(block *crypto.Block) error {
 
 	blockHeightByte := make([]byte, 8)
 	binary.LittleEndian.PutUint64(blockHeightByte, block.Height)
-	for _, tx := range block.Transactions() {
+	for i, tx := range block.Transactions() {
 		ms.Put(
 			ms.encodeTxHashToBlockHeightKey(tx.Hash()),
 			blockHeightByte,
 		)
-	}
 
-	for _, receipt := range block.Receipts() {
+		indexByte := make([]byte, 4)
+		binary.LittleEndian.PutUint32(indexByte, uint32(i))
 		ms.Put(
-			ms.encodeTxHashToReceiptHashKey(receipt.Transaction),
-			receipt.Hash().Bytes(),
+			ms.encodeTxHashToIndexKey(tx.Hash()),
+			indexByte,
 		)
 	}
 
@@ -81,8 +94,122 @@ func (ms *MetaStorage) TxHashToBlockHeight(txHash common.Hash) (uint64, error) {
 	return binary.LittleEndian.Uint64(blockHeightByte), nil
 }
 
-// TxHashToReceiptHash retrieves height of block which contains tx
-func (ms *MetaStorage) TxHashToReceiptHash(txHash common.Hash) common.Hash {
-	receiptHashBytes := ms.Get(ms.encodeTxHashToReceiptHashKey(txHash))
-	return common.BytesToHash(receiptHashBytes)
+// TxHashToIndex retrieves index of tx and its receipt in block which contains tx
+func (ms *MetaStorage) TxHashToIndex(txHash common.Hash) (uint32, error) {
+	indexByte := ms.Get(ms.encodeTxHashToIndexKey(txHash))
+	if len(indexByte) == 0 {
+		return 0, ErrTransactionNotFound
+	}
+	return binary.LittleEndian.Uint32(indexByte), nil
+}
+
+// StoreGenesis keeps application state of genesis for later restarts
+func (ms *MetaStorage) StoreGenesis(appState []byte) {
+	ms.Put(ms.encodeGenesisKey(), appState)
+}
+
+// Genesis retrieves application state of genesis
+func (ms *MetaStorage) Genesis() []byte {
+	return ms.Get(ms.encodeGenesisKey())
+}
+
+// StoreChainID keeps chain ID of genesis for later restarts
+func (ms *MetaStorage) StoreChainID(chainID string) {
+	ms.Put(ms.encodeChainIDKey(), []byte(chainID))
+}
+
+// ChainID retrieves chain ID of genesis
+func (ms *MetaStorage) ChainID() string {
+	return string(ms.Get(ms.encodeChainIDKey()))
+}
+
+// StoreGasStation keeps id of gas station active in block at height
+func (ms *MetaStorage) StoreGasStation(height uint64, station byte) {
+	ms.Put(ms.encodeGasStationKey(height), []byte{station})
+}
+
+// GasStation retrieves id of gas station active in block at height
+func (ms *MetaStorage) GasStation(height uint64) (byte, bool) {
+	station := ms.Get(ms.encodeGasStationKey(height))
+	if len(station) == 0 {
+		return 0, false
+	}
+	return station[0], true
+}
+
+// StoreAddressTransaction appends tx at index of block at height to transactions of address, txs are stored
+// by height and index so positions of an address follow execution order. Txs at or before the last stored
+// one are skipped so indexing a replayed block does not duplicate them.
+func (ms *MetaStorage) StoreAddressTransaction(address crypto.Address, height uint64, index uint32, txHash common.Hash, roles AddressRole) {
+	position := ms.AddressTransactionCount(address)
+	if position > 0 {
+		last := ms.Get(ms.encodeAddressTxKey(address, position-1))
+		if len(last) == addressTxEntryLength {
+			lastHeight := binary.LittleEndian.Uint64(last[common.HashLength+1:])
+			lastIndex := binary.LittleEndian.Uint32(last[common.HashLength+9:])
+			if lastHeight > height || lastHeight == height && lastIndex >= index {
+				return
+			}
+		}
+	}
+
+	entry := make([]byte, addressTxEntryLength)
+	copy(entry, txHash.Bytes())
+	entry[common.HashLength] = byte(roles)
+	binary.LittleEndian.PutUint64(entry[common.HashLength+1:], height)
+	binary.LittleEndian.PutUint32(entry[common.HashLength+9:], index)
+	ms.Put(ms.encodeAddressTxKey(address, position), entry)
+
+	countByte := make([]byte, 8)
+	binary.LittleEndian.PutUint64(countByte, position+1)
+	ms.Put(ms.encodeAddressTxCountKey(address), countByte)
+
+	// Each role also lists positions of its txs so filtering by role does not scan the others
+	positionByte := make([]byte, 8)
+	binary.LittleEndian.PutUint64(positionByte, position)
+	for _, role := range []AddressRole{AddressRoleSender, AddressRoleReceiver, AddressRoleEvent} {
+		if roles&role == 0 {
+			continue
+		}
+		rolePosition := ms.AddressRoleTransactionCount(address, role)
+		ms.Put(ms.encodeAddressRoleTxKey(address, role, rolePosition), positionByte)
+		binary.LittleEndian.PutUint64(countByte, rolePosition+1)
+		ms.Put(ms.encodeAddressRoleTxCountKey(address, role), countByte)
+	}
+}
+
+// AddressTransactionCount retrieves number of transactions involving address
+func (ms *MetaStorage) AddressTransactionCount(address crypto.Address) uint64 {
+	countByte := ms.Get(ms.encodeAddressTxCountKey(address))
+	if len(countByte) == 0 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(countByte)
+}
+
+// AddressTransaction retrieves hash of tx at position in transactions of address and roles of address in it
+func (ms *MetaStorage) AddressTransaction(address crypto.Address, position uint64) (common.Hash, AddressRole, error) {
+	entry := ms.Get(ms.encodeAddressTxKey(address, position))
+	if len(entry) != addressTxEntryLength {
+		return common.EmptyHash, 0, ErrTransactionNotFound
+	}
+	return common.BytesToHash(entry[:common.HashLength]), AddressRole(entry[common.HashLength]), nil
+}
+
+// AddressRoleTransactionCount retrieves number of transactions involving address in role
+func (ms *MetaStorage) AddressRoleTransactionCount(address crypto.Address, role AddressRole) uint64 {
+	countByte := ms.Get(ms.encodeAddressRoleTxCountKey(address, role))
+	if len(countByte) == 0 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(countByte)
+}
+
+// AddressRoleTransaction retrieves tx at position in transactions involving address in role, like AddressTransaction
+func (ms *MetaStorage) AddressRoleTransaction(address crypto.Address, role AddressRole, position uint64) (common.Hash, AddressRole, error) {
+	positionByte := ms.Get(ms.encodeAddressRoleTxKey(address, role, position))
+	if len(positionByte) != 8 {
+		return common.EmptyHash, 0, ErrTransactionNotFound
+	}
+	return ms.AddressTransaction(address, binary.LittleEndian.Uint64(positionByte))
 }
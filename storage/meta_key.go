@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 
 	"github.com/QuoineFinancial/liquid-chain/common"
+	"github.com/QuoineFinancial/liquid-chain/crypto"
 )
 
 // metaKeyPrefix is type of prefix keys for indexing
@@ -13,11 +14,53 @@ const (
 	blockHeightToBlockHashPrefix metaKeyPrefix = 0x0
 	txHashToBlockHeightPrefix    metaKeyPrefix = 0x1
 	latestBlockHeightPrefix      metaKeyPrefix = 0x2
-	txHashToReceiptHashPrefix    metaKeyPrefix = 0x3
+	txHashToReceiptHashPrefix    metaKeyPrefix = 0x3 // legacy receipt hash index, kept reserved
+	genesisPrefix                metaKeyPrefix = 0x4
+	gasStationPrefix             metaKeyPrefix = 0x5
+	chainIDPrefix                metaKeyPrefix = 0x6
+	addressTxCountPrefix         metaKeyPrefix = 0x7
+	addressTxPrefix              metaKeyPrefix = 0x8
+	txHashToIndexPrefix          metaKeyPrefix = 0x9
+	addressRoleTxCountPrefix     metaKeyPrefix = 0xa
+	addressRoleTxPrefix          metaKeyPrefix = 0xb
 )
 
-func (index *MetaStorage) encodeTxHashToReceiptHashKey(hash common.Hash) []byte {
-	return index.encodeKey(txHashToReceiptHashPrefix, hash.Bytes())
+func (index *MetaStorage) encodeAddressTxCountKey(address crypto.Address) []byte {
+	return index.encodeKey(addressTxCountPrefix, address[:])
+}
+
+func (index *MetaStorage) encodeAddressTxKey(address crypto.Address, position uint64) []byte {
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, position)
+	return index.encodeKey(addressTxPrefix, append(address[:], key...))
+}
+
+func (index *MetaStorage) encodeAddressRoleTxCountKey(address crypto.Address, role AddressRole) []byte {
+	return index.encodeKey(addressRoleTxCountPrefix, append(address[:], byte(role)))
+}
+
+func (index *MetaStorage) encodeAddressRoleTxKey(address crypto.Address, role AddressRole, position uint64) []byte {
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, position)
+	return index.encodeKey(addressRoleTxPrefix, append(append(address[:], byte(role)), key...))
+}
+
+func (index *MetaStorage) encodeChainIDKey() []byte {
+	return index.encodeKey(chainIDPrefix, []byte{})
+}
+
+func (index *MetaStorage) encodeGasStationKey(height uint64) []byte {
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, height)
+	return index.encodeKey(gasStationPrefix, key)
+}
+
+func (index *MetaStorage) encodeGenesisKey() []byte {
+	return index.encodeKey(genesisPrefix, []byte{})
+}
+
+func (index *MetaStorage) encodeTxHashToIndexKey(hash common.Hash) []byte {
+	return index.encodeKey(txHashToIndexPrefix, hash.Bytes())
 }
 
 func (index *MetaStorage) encodeTxHashToBlockHeightKey(hash common.Hash) []byte {
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+func (state *StateStorage) loadRewardRegistryAccount() (*Account, error) {
+	account, err := state.LoadAccount(crypto.RewardRegistryAddress)
+	if err != nil || account != nil {
+		return account, err
+	}
+	return state.CreateAccount(crypto.RewardRegistryAddress, crypto.RewardRegistryAddress, nil)
+}
+
+// SetRewardAddress stores address receiving fees of Tendermint validator
+func (state *StateStorage) SetRewardAddress(validator []byte, address crypto.Address) error {
+	account, err := state.loadRewardRegistryAccount()
+	if err != nil {
+		return err
+	}
+	return account.SetStorage(validator, address[:])
+}
+
+// RewardAddress returns address receiving fees of Tendermint validator, false if validator has not set one
+func (state *StateStorage) RewardAddress(validator []byte) (crypto.Address, bool, error) {
+	account, err := state.LoadAccount(crypto.RewardRegistryAddress)
+	if err != nil || account == nil {
+		return crypto.EmptyAddress, false, err
+	}
+	raw, err := account.GetStorage(validator)
+	if err != nil || len(raw) == 0 {
+		return crypto.EmptyAddress, false, err
+	}
+	address, err := crypto.AddressFromBytes(raw)
+	return address, err == nil, err
+}
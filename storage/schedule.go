@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+)
+
+// Schedule account storage keys, calls are keyed by due height or time then sequence so
+// iterating storage visits them in execution order
+var (
+	scheduleSequenceKey  = []byte("sequence")
+	scheduleHeightPrefix = []byte{'h'}
+	scheduleTimePrefix   = []byte{'t'}
+	scheduleKeySize      = 1 + 8 + 8
+)
+
+func scheduleKey(call *crypto.ScheduledCall) []byte {
+	key := make([]byte, 0, scheduleKeySize)
+	due := call.Height
+	if call.Height > 0 {
+		key = append(key, scheduleHeightPrefix...)
+	} else {
+		key = append(key, scheduleTimePrefix...)
+		due = call.Time
+	}
+	key = append(key, make([]byte, 16)...)
+	binary.BigEndian.PutUint64(key[1:], due)
+	binary.BigEndian.PutUint64(key[9:], call.Sequence)
+	return key
+}
+
+func (state *StateStorage) loadScheduleAccount() (*Account, error) {
+	account, err := state.LoadAccount(crypto.ScheduleAddress)
+	if err != nil || account != nil {
+		return account, err
+	}
+	return state.CreateAccount(crypto.ScheduleAddress, crypto.ScheduleAddress, nil)
+}
+
+// Schedule queues call and assigns its sequence
+func (state *StateStorage) Schedule(call *crypto.ScheduledCall) error {
+	account, err := state.loadScheduleAccount()
+	if err != nil {
+		return err
+	}
+	raw, err := account.GetStorage(scheduleSequenceKey)
+	if err != nil {
+		return err
+	}
+	if len(raw) > 0 {
+		call.Sequence = binary.BigEndian.Uint64(raw)
+	}
+	next := make([]byte, 8)
+	binary.BigEndian.PutUint64(next, call.Sequence+1)
+	if err := account.SetStorage(scheduleSequenceKey, next); err != nil {
+		return err
+	}
+	encoded, err := call.Encode()
+	if err != nil {
+		return err
+	}
+	return account.SetStorage(scheduleKey(call), encoded)
+}
+
+// Unschedule removes call from queue
+func (state *StateStorage) Unschedule(call *crypto.ScheduledCall) error {
+	account, err := state.loadScheduleAccount()
+	if err != nil {
+		return err
+	}
+	return account.SetStorage(scheduleKey(call), nil)
+}
+
+// DueCalls returns calls due at height or time in execution order, calls due by height come first.
+// Calls are taken while their total gas limit fits gasLimit so later calls wait for next blocks,
+// the first call is always taken so a call exceeding gasLimit alone does not block the queue.
+func (state *StateStorage) DueCalls(height, time, gasLimit uint64) ([]*crypto.ScheduledCall, error) {
+	var due []*crypto.ScheduledCall
+	for _, queue := range []struct {
+		prefix []byte
+		until  uint64
+	}{{scheduleHeightPrefix, height}, {scheduleTimePrefix, time}} {
+		calls, err := state.scheduledCalls(queue.prefix, queue.until)
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range calls {
+			if uint64(call.GasLimit) > gasLimit {
+				if len(due) > 0 {
+					return due, nil
+				}
+				gasLimit = uint64(call.GasLimit)
+			}
+			gasLimit -= uint64(call.GasLimit)
+			due = append(due, call)
+		}
+	}
+	return due, nil
+}
+
+// ScheduledCalls returns every queued call in execution order
+func (state *StateStorage) ScheduledCalls() ([]*crypto.ScheduledCall, error) {
+	byHeight, err := state.scheduledCalls(scheduleHeightPrefix, 0)
+	if err != nil {
+		return nil, err
+	}
+	byTime, err := state.scheduledCalls(scheduleTimePrefix, 0)
+	if err != nil {
+		return nil, err
+	}
+	return append(byHeight, byTime...), nil
+}
+
+// scheduledCalls returns calls of queue under prefix due until given point, zero returns all of them
+func (state *StateStorage) scheduledCalls(prefix []byte, until uint64) ([]*crypto.ScheduledCall, error) {
+	account, err := state.LoadAccount(crypto.ScheduleAddress)
+	if err != nil || account == nil {
+		return nil, err
+	}
+	var calls []*crypto.ScheduledCall
+	it := account.StorageIterator(prefix)
+	for it.Next() {
+		if !bytes.HasPrefix(it.Key, prefix) || len(it.Key) != scheduleKeySize {
+			break
+		}
+		if until > 0 && binary.BigEndian.Uint64(it.Key[1:]) > until {
+			break
+		}
+		call, err := crypto.DecodeScheduledCall(it.Value)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return calls, it.Err
+}
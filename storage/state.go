@@ -15,6 +15,9 @@ type StateStorage struct {
 	stateTrie         *trie.Trie
 	accounts          map[crypto.Address]*Account
 	accountCheckpoint common.Hash
+	trackStorageSize  bool
+	executingBlock    *crypto.Block
+	scheduleGasLimit  uint64
 }
 
 // NewStateStorage returns a state storage
@@ -37,6 +40,7 @@ func (state *StateStorage) LoadState(block *crypto.Block) error {
 	}
 
 	state.block = block
+	state.executingBlock = nil
 	state.stateTrie = stateTrie
 	state.accountCheckpoint = block.StateRoot
 	state.accounts = make(map[crypto.Address]*Account)
@@ -44,6 +48,45 @@ func (state *StateStorage) LoadState(block *crypto.Block) error {
 	return nil
 }
 
+// SetStorageSizeTracking makes accounts loaded afterwards keep size of their storage
+func (state *StateStorage) SetStorageSizeTracking(enabled bool) {
+	state.trackStorageSize = enabled
+}
+
+// SetExecutingBlock sets block being executed on top of loaded state
+func (state *StateStorage) SetExecutingBlock(block *crypto.Block) {
+	state.executingBlock = block
+}
+
+// ExecutingBlock returns block being executed on top of loaded state, nil outside of block execution
+func (state *StateStorage) ExecutingBlock() *crypto.Block {
+	return state.executingBlock
+}
+
+// SetScheduleGasLimit sets gas limit of scheduled calls run in a block, zero disables scheduling
+func (state *StateStorage) SetScheduleGasLimit(gasLimit uint64) {
+	state.scheduleGasLimit = gasLimit
+}
+
+// ScheduleGasLimit returns gas limit of scheduled calls run in a block
+func (state *StateStorage) ScheduleGasLimit() uint64 {
+	return state.scheduleGasLimit
+}
+
+// ResizedAccounts returns loaded rent paying accounts whose storage size changed since rent was last settled
+func (state *StateStorage) ResizedAccounts() []*Account {
+	var resized []*Account
+	for _, account := range state.accounts {
+		if account == nil || account.deleted || account.PaidUntil == 0 {
+			continue
+		}
+		if account.StorageSize != account.settledSize {
+			resized = append(resized, account)
+		}
+	}
+	return resized
+}
+
 // GetBlock returns block that inits current state
 func (state *StateStorage) GetBlock() *crypto.Block {
 	return state.block
@@ -57,6 +100,13 @@ func (state *StateStorage) Hash() common.Hash {
 			continue
 		}
 
+		if account.deleted {
+			if err = state.stateTrie.Update(account.address[:], nil); err != nil {
+				panic(err)
+			}
+			continue
+		}
+
 		// Update account storage
 		account.StorageHash = account.storage.Hash()
 
@@ -77,6 +127,14 @@ func (state *StateStorage) Commit() common.Hash {
 			continue
 		}
 
+		if account.deleted {
+			if err := state.stateTrie.Update(account.address[:], nil); err != nil {
+				panic(err)
+			}
+			account.dirty = false
+			continue
+		}
+
 		if account.IsContract() {
 			// Update contract
 			state.Put(account.ContractHash.Bytes(), account.contract)
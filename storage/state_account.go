@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"crypto/ed25519"
+	"io"
+
 	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
 	"github.com/QuoineFinancial/liquid-chain/abi"
 	"github.com/QuoineFinancial/liquid-chain/common"
@@ -16,10 +19,53 @@ type Account struct {
 	StorageHash  common.Hash    `json:"storageHash"`
 	Creator      crypto.Address `json:"creator"`
 
-	dirty    bool
-	address  crypto.Address
-	storage  *trie.Trie
-	contract []byte
+	// Optional fields, StorageSize and PaidUntil are only kept when storage rent is enabled
+	StorageSize uint64         `json:"storageSize"`
+	PaidUntil   uint64         `json:"paidUntil"`
+	Admin       crypto.Address `json:"admin"`
+	CodeHistory []common.Hash  `json:"codeHistory"`
+	Balance     uint64         `json:"balance"`
+
+	// Keys controlling multisig account, sorted, and number of them required to sign
+	MultisigKeys      []ed25519.PublicKey `json:"multisigKeys"`
+	MultisigThreshold uint64              `json:"multisigThreshold"`
+
+	dirty       bool
+	deleted     bool
+	trackSize   bool
+	settledSize uint64
+	address     crypto.Address
+	storage     *trie.Trie
+	contract    []byte
+}
+
+func (account *Account) fields() []interface{} {
+	return []interface{}{
+		&account.Nonce,
+		&account.ContractHash,
+		&account.StorageHash,
+		&account.Creator,
+		&account.StorageSize,
+		&account.PaidUntil,
+		&account.Admin,
+		&account.CodeHistory,
+		&account.Balance,
+		&account.MultisigKeys,
+		&account.MultisigThreshold,
+	}
+}
+
+// accountFieldCount is number of fields every account encodes
+const accountFieldCount = 4
+
+// EncodeRLP encodes account as a list, trailing empty optional fields are omitted
+func (account Account) EncodeRLP(w io.Writer) error {
+	return crypto.EncodeFields(w, account.fields(), accountFieldCount)
+}
+
+// DecodeRLP decodes account, missing optional fields are left empty
+func (account *Account) DecodeRLP(s *rlp.Stream) error {
+	return crypto.DecodeFields(s, account.fields(), accountFieldCount)
 }
 
 // loadAccount load the account from disk
@@ -36,6 +82,8 @@ func (state *StateStorage) loadAccount(address crypto.Address) (*Account, error)
 		return nil, err
 	}
 	account.address = address
+	account.trackSize = state.trackStorageSize
+	account.settledSize = account.StorageSize
 	account.contract = state.Get(account.ContractHash.Bytes())
 	if account.storage, err = trie.New(account.StorageHash, state.Database); err != nil {
 		return nil, err
@@ -61,9 +109,21 @@ func (state *StateStorage) LoadAccount(address crypto.Address) (*Account, error)
 		}
 		state.accounts[address] = loadedAccount
 	}
+	if account := state.accounts[address]; account != nil && account.deleted {
+		return nil, nil
+	}
 	return state.accounts[address], nil
 }
 
+// DeleteAccount removes account at addr from state on next commit
+func (state *StateStorage) DeleteAccount(address crypto.Address) {
+	state.accounts[address] = &Account{
+		address: address,
+		dirty:   true,
+		deleted: true,
+	}
+}
+
 // CreateAccount create a new account state for addr
 func (state *StateStorage) CreateAccount(creator crypto.Address, address crypto.Address, contract []byte) (*Account, error) {
 	storage, err := trie.New(common.Hash{}, state.Database)
@@ -71,12 +131,13 @@ func (state *StateStorage) CreateAccount(creator crypto.Address, address crypto.
 		return nil, err
 	}
 	account := &Account{
-		Nonce:    0,
-		Creator:  creator,
-		address:  address,
-		storage:  storage,
-		contract: contract,
-		dirty:    true,
+		Nonce:     0,
+		Creator:   creator,
+		address:   address,
+		storage:   storage,
+		contract:  contract,
+		dirty:     true,
+		trackSize: state.trackStorageSize,
 	}
 
 	account.setContract(contract)
@@ -92,9 +153,48 @@ func (account *Account) GetStorage(key []byte) ([]byte, error) {
 // SetStorage set the account storage
 func (account *Account) SetStorage(key, value []byte) error {
 	account.dirty = true
+	if account.trackSize {
+		current, err := account.storage.Get(key)
+		if err != nil {
+			return err
+		}
+		if len(current) > 0 {
+			account.StorageSize -= uint64(len(key) + len(current))
+		}
+		if len(value) > 0 {
+			account.StorageSize += uint64(len(key) + len(value))
+		}
+	}
 	return account.storage.Update(key, value)
 }
 
+// StorageIterator iterates storage of account in key order from start key
+func (account *Account) StorageIterator(start []byte) *trie.Iterator {
+	return trie.NewIterator(account.storage.NodeIterator(start))
+}
+
+// RentSize is number of bytes account pays rent for, its contract code and storage
+func (account *Account) RentSize() uint64 {
+	return uint64(len(account.contract)) + account.StorageSize
+}
+
+// SettledRentSize is rent size when rent of account was last settled
+func (account *Account) SettledRentSize() uint64 {
+	return uint64(len(account.contract)) + account.settledSize
+}
+
+// SettleRent stores the last height covered by paid rent for current storage size
+func (account *Account) SettleRent(paidUntil uint64) {
+	account.dirty = true
+	account.PaidUntil = paidUntil
+	account.settledSize = account.StorageSize
+}
+
+// Dormant checks whether rent of account is behind at height, accounts never charged rent are not dormant
+func (account *Account) Dormant(height uint64) bool {
+	return account.PaidUntil > 0 && height > account.PaidUntil
+}
+
 // GetAddress returns state address
 func (account *Account) GetAddress() crypto.Address {
 	return account.address
@@ -121,6 +221,33 @@ func (account *Account) GetCreator() crypto.Address {
 	return account.Creator
 }
 
+// SetAdmin records address allowed to upgrade contract instead of its creator
+func (account *Account) SetAdmin(admin crypto.Address) {
+	account.dirty = true
+	account.Admin = admin
+}
+
+// SetMultisig replaces keys and threshold controlling multisig account
+func (account *Account) SetMultisig(keys []ed25519.PublicKey, threshold uint64) {
+	account.dirty = true
+	account.MultisigKeys = keys
+	account.MultisigThreshold = threshold
+}
+
+// CanUpgrade checks whether address may upgrade contract, it is admin if set, otherwise creator
+func (account *Account) CanUpgrade(address crypto.Address) bool {
+	if account.Admin != crypto.EmptyAddress {
+		return address == account.Admin
+	}
+	return address == account.Creator
+}
+
+// UpgradeContract replaces contract code keeping storage, hash of replaced code is appended to history
+func (account *Account) UpgradeContract(contract []byte) {
+	account.CodeHistory = append(account.CodeHistory, account.ContractHash)
+	account.setContract(contract)
+}
+
 func (account *Account) setContract(contract []byte) {
 	account.dirty = true
 	account.contract = contract
@@ -0,0 +1,22 @@
+(module
+  (type $t0 (func (param i32) (result i32)))
+  (type $t1 (func (param i32) (result i64)))
+  (type $t2 (func (param i32 i64 i64) (result i32)))
+  (type $t3 (func (result i32)))
+  (import "env" "chain_self_destruct" (func $chain_self_destruct (type $t0)))
+  (func $get_balance (type $t1) (param $p0 i32) (result i64)
+    i64.const 1000000000000)
+  (func $transfer (type $t2) (param $p0 i32) (param $p1 i64) (param $p2 i64) (result i32)
+    i32.const 0)
+  (func $destroy (type $t3) (result i32)
+    i32.const 0
+    call $chain_self_destruct
+    drop
+    i32.const 0)
+  (memory $memory 1)
+  (global $__data_end i32 (i32.const 1024))
+  (export "memory" (memory 0))
+  (export "__data_end" (global 0))
+  (export "get_balance" (func $get_balance))
+  (export "transfer" (func $transfer))
+  (export "destroy" (func $destroy)))
@@ -0,0 +1,31 @@
+package token
+
+import (
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+// NativeToken moves native balances of accounts, it is backed by no contract
+type NativeToken struct {
+	state *storage.StateStorage
+}
+
+// GetBalance retrieve native balance by address
+func (token *NativeToken) GetBalance(addr crypto.Address) (uint64, error) {
+	return token.state.GetBalance(addr)
+}
+
+// Transfer native balance from caller address to another address, no event is emitted
+func (token *NativeToken) Transfer(caller crypto.Address, addr crypto.Address, amount uint64, memo uint64) ([]*crypto.Event, error) {
+	return nil, token.state.Transfer(caller, addr, amount)
+}
+
+// GetContract returns nil, native balance has no contract
+func (token *NativeToken) GetContract() *storage.Account {
+	return nil
+}
+
+// NewNativeToken over state
+func NewNativeToken(state *storage.StateStorage) *NativeToken {
+	return &NativeToken{state: state}
+}
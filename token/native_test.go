@@ -0,0 +1,46 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/crypto"
+	"github.com/QuoineFinancial/liquid-chain/db"
+	"github.com/QuoineFinancial/liquid-chain/storage"
+)
+
+func TestNativeToken(t *testing.T) {
+	state := storage.NewStateStorage(db.NewMemoryDB())
+	if err := state.LoadState(&crypto.GenesisBlock); err != nil {
+		panic(err)
+	}
+	ownerAddress, _ := crypto.AddressFromString(ownerAddressStr)
+	otherAddress, _ := crypto.AddressFromString(otherAddressStr)
+	if err := state.AddBalance(ownerAddress, 1000); err != nil {
+		panic(err)
+	}
+	token := NewNativeToken(state)
+
+	events, err := token.Transfer(ownerAddress, otherAddress, 400, 0)
+	if err != nil {
+		panic(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expect no event, got %v", len(events))
+	}
+	if ret, _ := token.GetBalance(ownerAddress); ret != 600 {
+		t.Errorf("Expect owner balance to be %v, got %v", 600, ret)
+	}
+	if ret, _ := token.GetBalance(otherAddress); ret != 400 {
+		t.Errorf("Expect other balance to be %v, got %v", 400, ret)
+	}
+
+	if _, err := token.Transfer(otherAddress, ownerAddress, 401, 0); err != storage.ErrInsufficientBalance {
+		t.Errorf("Expect error %v, got %v", storage.ErrInsufficientBalance, err)
+	}
+	if ret, _ := token.GetBalance(otherAddress); ret != 400 {
+		t.Errorf("Expect other balance to be kept %v, got %v", 400, ret)
+	}
+	if token.GetContract() != nil {
+		t.Error("Expect native token without contract")
+	}
+}
@@ -0,0 +1,123 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/QuoineFinancial/liquid-chain-rlp/rlp"
+	"github.com/QuoineFinancial/liquid-chain/common"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Prove returns encoded nodes on the path of key from root, nodes embedded in their parent are left out.
+// The proof of a missing key holds the nodes showing it is absent.
+func (tree *Trie) Prove(key []byte) ([][]byte, error) {
+	key = keybytesToHex(key)
+	var nodes []Node
+	currentNode := tree.root
+	for len(key) > 0 && currentNode != nil {
+		switch node := currentNode.(type) {
+		case *shortNode:
+			if len(key) < len(node.Key) || !bytes.Equal(node.Key, key[:len(node.Key)]) {
+				currentNode = nil
+			} else {
+				currentNode = node.Value
+				key = key[len(node.Key):]
+			}
+			nodes = append(nodes, node)
+		case *branchNode:
+			currentNode = node.Children[key[0]]
+			key = key[1:]
+			nodes = append(nodes, node)
+		case hashNode:
+			loadedNode, err := tree.loadNode(node)
+			if err != nil {
+				return nil, err
+			}
+			currentNode = loadedNode
+		case valueNode:
+			currentNode = nil
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", currentNode, currentNode))
+		}
+	}
+
+	hasher := newHasher()
+	defer returnHasherToPool(hasher)
+	var proof [][]byte
+	for i, node := range nodes {
+		collapsed, _, err := hasher.hashChildren(node, nil)
+		if err != nil {
+			return nil, err
+		}
+		hashed, err := hasher.store(collapsed, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		// Root is always referred by hash
+		if _, isHash := hashed.(hashNode); isHash || i == 0 {
+			encoded, err := rlp.EncodeToBytes(collapsed)
+			if err != nil {
+				return nil, err
+			}
+			proof = append(proof, encoded)
+		}
+	}
+	return proof, nil
+}
+
+// VerifyProof checks proof of key against root hash and returns value of key, nil value means key is absent
+func VerifyProof(rootHash common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	proofNodes := make(map[common.Hash][]byte, len(proof))
+	for _, encoded := range proof {
+		proofNodes[blake2b.Sum256(encoded)] = encoded
+	}
+
+	key = keybytesToHex(key)
+	wantHash := rootHash
+	for {
+		encoded, ok := proofNodes[wantHash]
+		if !ok {
+			return nil, fmt.Errorf("Proof node %x is missing", wantHash.Bytes())
+		}
+		node, err := decodeNode(wantHash.Bytes(), encoded)
+		if err != nil {
+			return nil, fmt.Errorf("Bad proof node %x: %v", wantHash.Bytes(), err)
+		}
+		rest, child := proofChild(node, key)
+		switch child := child.(type) {
+		case nil:
+			return nil, nil
+		case hashNode:
+			key = rest
+			wantHash = common.BytesToHash(child)
+		case valueNode:
+			return child, nil
+		}
+	}
+}
+
+// proofChild follows key through node and embedded nodes until it reaches a value or a hash reference
+func proofChild(currentNode Node, key []byte) ([]byte, Node) {
+	for {
+		switch node := currentNode.(type) {
+		case *shortNode:
+			if len(key) < len(node.Key) || !bytes.Equal(node.Key, key[:len(node.Key)]) {
+				return nil, nil
+			}
+			currentNode = node.Value
+			key = key[len(node.Key):]
+		case *branchNode:
+			currentNode = node.Children[key[0]]
+			key = key[1:]
+		case hashNode:
+			return key, node
+		case valueNode:
+			return nil, node
+		case nil:
+			return key, nil
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", currentNode, currentNode))
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package trie
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/QuoineFinancial/liquid-chain/common"
+)
+
+func indexKey(i uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, i)
+	return key
+}
+
+func TestProve(t *testing.T) {
+	trie := newEmpty()
+	for i := uint32(0); i < 300; i++ {
+		// Short values are embedded in their parent
+		value := []byte{byte(i)}
+		if i%2 == 0 {
+			value = bytes.Repeat([]byte{byte(i)}, 40)
+		}
+		trie.Update(indexKey(i), value)
+	}
+	root, err := trie.Commit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := New(root, trie.db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tree := range []*Trie{trie, loaded} {
+		for i := uint32(0); i < 300; i++ {
+			proof, err := tree.Prove(indexKey(i))
+			if err != nil {
+				t.Fatal(err)
+			}
+			value, err := VerifyProof(root, indexKey(i), proof)
+			if err != nil {
+				t.Fatalf("Key %d: %v", i, err)
+			}
+			if want, _ := trie.Get(indexKey(i)); !bytes.Equal(value, want) {
+				t.Errorf("Key %d: got %x, want %x", i, value, want)
+			}
+		}
+	}
+
+	proof, err := loaded.Prove(indexKey(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, err := VerifyProof(root, indexKey(1000), proof); err != nil || value != nil {
+		t.Errorf("Missing key: got %x %v, want nil", value, err)
+	}
+}
+
+func TestVerifyProofBad(t *testing.T) {
+	trie := newEmpty()
+	for i := uint32(0); i < 50; i++ {
+		updateString(trie, fmt.Sprintf("key%d", i), fmt.Sprintf("value of key %d padded beyond hash size", i))
+	}
+	root := trie.Hash()
+	proof, err := trie.Prove([]byte("key7"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := VerifyProof(common.BytesToHash([]byte{1}), []byte("key7"), proof); err == nil {
+		t.Error("Expected error for wrong root")
+	}
+	if _, err := VerifyProof(root, []byte("key7"), proof[:len(proof)-1]); err == nil {
+		t.Error("Expected error for truncated proof")
+	}
+	tampered := append([][]byte{}, proof...)
+	last := append([]byte{}, tampered[len(tampered)-1]...)
+	last[len(last)-1] ^= 0xff
+	tampered[len(tampered)-1] = last
+	if _, err := VerifyProof(root, []byte("key7"), tampered); err == nil {
+		t.Error("Expected error for tampered proof")
+	}
+}